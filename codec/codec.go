@@ -0,0 +1,76 @@
+// Package codec encodes and decodes uscdl containers at runtime from a loaded
+// config, producing the same wire bytes as the generated serializers without
+// regenerating or compiling code.
+package codec
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sammyjroberts/uscdl/model"
+)
+
+// ErrShortData is returned when a payload ends before a container is fully
+// decoded
+var ErrShortData = errors.New("payload too short")
+
+// Codec encodes and decodes the containers of one config
+type Codec struct {
+	config *model.Config
+}
+
+// New returns a Codec for a config loaded with model.Load or model.Parse
+func New(config *model.Config) *Codec {
+	return &Codec{config: config}
+}
+
+// Config returns the config the codec was created with
+func (c *Codec) Config() *model.Config {
+	return c.config
+}
+
+// container looks up a container by name
+func (c *Codec) container(name string) (*model.Container, error) {
+	container := c.config.Container(name)
+	if container == nil {
+		return nil, fmt.Errorf("unknown container %q", name)
+	}
+	return container, nil
+}
+
+// Decode decodes a container from the start of data into a map keyed by item
+// name. Trailing bytes are ignored.
+func (c *Codec) Decode(containerName string, data []byte) (map[string]any, error) {
+	value, err := c.DecodeValue(containerName, data)
+	if err != nil {
+		return nil, err
+	}
+	return value.Interface().(map[string]any), nil
+}
+
+// DecodeValue decodes a container from the start of data into a value tree.
+// The root value's Size is the number of bytes consumed.
+func (c *Codec) DecodeValue(containerName string, data []byte) (*Value, error) {
+	container, err := c.container(containerName)
+	if err != nil {
+		return nil, err
+	}
+	d := &decoder{config: c.config, data: data}
+	return d.container(container, containerName)
+}
+
+// Encode encodes a container from a map keyed by item name, as produced by
+// Decode or by unmarshalling JSON. Missing items take their default value;
+// unknown items are an error. Decode JSON with json.Decoder.UseNumber to keep
+// 64-bit integers exact.
+func (c *Codec) Encode(containerName string, values map[string]any) ([]byte, error) {
+	container, err := c.container(containerName)
+	if err != nil {
+		return nil, err
+	}
+	e := &encoder{config: c.config}
+	if err := e.container(container, values, containerName); err != nil {
+		return nil, err
+	}
+	return e.buf, nil
+}
@@ -0,0 +1,212 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/sammyjroberts/uscdl/model"
+)
+
+// decoder walks a payload following a container layout
+type decoder struct {
+	config *model.Config
+	data   []byte
+	off    int
+}
+
+// take consumes n bytes of the payload
+func (d *decoder) take(n int, path string) ([]byte, error) {
+	if d.off+n > len(d.data) {
+		return nil, fmt.Errorf("%s: need %d bytes at offset %d, have %d: %w", path, n, d.off, len(d.data)-d.off, ErrShortData)
+	}
+	b := d.data[d.off : d.off+n]
+	d.off += n
+	return b, nil
+}
+
+// container decodes every item of a container
+func (d *decoder) container(container *model.Container, path string) (*Value, error) {
+	value := &Value{Name: container.Name, Kind: KindContainer, Offset: d.off}
+	for i := range container.Items {
+		item := &container.Items[i]
+		field, err := d.item(item, value, path+"."+item.Name)
+		if err != nil {
+			return nil, err
+		}
+		value.Fields = append(value.Fields, field)
+	}
+	value.Size = d.off - value.Offset
+	return value, nil
+}
+
+// item decodes an item, which may be an array; parent holds the items of the
+// enclosing container decoded so far
+func (d *decoder) item(item *model.Item, parent *Value, path string) (*Value, error) {
+	if !item.IsArray {
+		value, err := d.element(item, path)
+		if err != nil {
+			return nil, err
+		}
+		value.Name = item.Name
+		return value, nil
+	}
+
+	value := &Value{Name: item.Name, Kind: KindArray, Item: item, Offset: d.off, Elements: []*Value{}}
+	count := item.Length
+	if item.IsVariableArray() {
+		if item.LengthField != "" {
+			counter := parent.Field(item.LengthField)
+			if counter == nil {
+				return nil, fmt.Errorf("%s: length field %q not decoded", path, item.LengthField)
+			}
+			count = int(toInt64Raw(counter.Raw))
+		} else {
+			raw, err := d.primitive(item.LengthType, item.ByteOrder, path)
+			if err != nil {
+				return nil, err
+			}
+			count = int(raw.(uint64))
+		}
+		if count < 0 || count > item.MaxLength {
+			return nil, fmt.Errorf("%s: %d elements exceed the maximum of %d", path, count, item.MaxLength)
+		}
+	}
+
+	for i := 0; i < count; i++ {
+		elementPath := fmt.Sprintf("%s[%d]", path, i)
+		element, err := d.element(item, elementPath)
+		if err != nil {
+			return nil, err
+		}
+		element.Name = fmt.Sprintf("%s[%d]", item.Name, i)
+		value.Elements = append(value.Elements, element)
+	}
+	value.Size = d.off - value.Offset
+	return value, nil
+}
+
+// element decodes a single element of an item
+func (d *decoder) element(item *model.Item, path string) (*Value, error) {
+	start := d.off
+	var value *Value
+	switch item.Type {
+	case "container":
+		child, err := d.container(d.config.Container(item.Container), path)
+		if err != nil {
+			return nil, err
+		}
+		value = child
+	case "string":
+		s, err := d.string(item, path)
+		if err != nil {
+			return nil, err
+		}
+		value = &Value{Kind: KindString, Raw: s}
+	case "enum":
+		enum := d.config.Enum(item.Enum)
+		raw, err := d.primitive(enum.Type, item.ByteOrder, path)
+		if err != nil {
+			return nil, err
+		}
+		value = &Value{Kind: KindEnum, Enum: enum, Raw: raw}
+	case "bitfield":
+		raw, err := d.primitive(item.BaseType, item.ByteOrder, path)
+		if err != nil {
+			return nil, err
+		}
+		value = &Value{Kind: KindBitfield, Raw: raw}
+	default:
+		raw, err := d.primitive(item.Type, item.ByteOrder, path)
+		if err != nil {
+			return nil, err
+		}
+		value = &Value{Kind: KindPrimitive, Raw: raw}
+	}
+	value.Item = item
+	value.Offset = start
+	value.Size = d.off - start
+	return value, nil
+}
+
+// string decodes a fixed-capacity or length-prefixed string
+func (d *decoder) string(item *model.Item, path string) (string, error) {
+	if item.Encoding == "prefixed" {
+		raw, err := d.primitive(item.LengthType, item.ByteOrder, path)
+		if err != nil {
+			return "", err
+		}
+		length := int(raw.(uint64))
+		if length > item.MaxLength {
+			return "", fmt.Errorf("%s: length %d exceeds the maximum of %d", path, length, item.MaxLength)
+		}
+		b, err := d.take(length, path)
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+	}
+
+	b, err := d.take(item.MaxLength, path)
+	if err != nil {
+		return "", err
+	}
+	if end := bytes.IndexByte(b, 0); end >= 0 {
+		b = b[:end]
+	}
+	return string(b), nil
+}
+
+// primitive decodes a primitive type as uint64, int64, float64 or bool
+func (d *decoder) primitive(itemType, byteOrder, path string) (any, error) {
+	size := PrimitiveSize(itemType)
+	if size == 0 {
+		return nil, fmt.Errorf("%s: unsupported type %q", path, itemType)
+	}
+	b, err := d.take(size, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var order binary.ByteOrder = binary.LittleEndian
+	if byteOrder == "big" {
+		order = binary.BigEndian
+	}
+	var bits uint64
+	switch size {
+	case 1:
+		bits = uint64(b[0])
+	case 2:
+		bits = uint64(order.Uint16(b))
+	case 4:
+		bits = uint64(order.Uint32(b))
+	case 8:
+		bits = order.Uint64(b)
+	}
+
+	switch itemType {
+	case "bool":
+		return bits != 0, nil
+	case "float":
+		return float64(math.Float32frombits(uint32(bits))), nil
+	case "double":
+		return math.Float64frombits(bits), nil
+	case "int8":
+		return int64(int8(bits)), nil
+	case "int16":
+		return int64(int16(bits)), nil
+	case "int32":
+		return int64(int32(bits)), nil
+	case "int64":
+		return int64(bits), nil
+	default:
+		return bits, nil
+	}
+}
+
+// PrimitiveSize returns the encoded size of a primitive type in bytes, or 0
+// for types that are not primitives
+func PrimitiveSize(itemType string) int {
+	return model.PrimitiveSize(itemType)
+}
@@ -0,0 +1,433 @@
+package codec
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/sammyjroberts/uscdl/model"
+)
+
+// encoder appends the wire bytes of a value tree to buf
+type encoder struct {
+	config *model.Config
+	buf    []byte
+}
+
+// container encodes every item of a container from a map keyed by item name
+func (e *encoder) container(container *model.Container, values map[string]any, path string) error {
+	var unknown []string
+	for name := range values {
+		if container.Item(name) == nil {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("%s: unknown items %v", path, unknown)
+	}
+
+	// Counts of variable-length arrays taken from their length field default
+	// to the number of elements supplied
+	counts := make(map[string]int)
+	for _, item := range container.Items {
+		if item.LengthField == "" {
+			continue
+		}
+		if elements, ok := values[item.Name].([]any); ok {
+			counts[item.LengthField] = len(elements)
+		}
+	}
+
+	for i := range container.Items {
+		item := &container.Items[i]
+		itemPath := path + "." + item.Name
+		value, ok := values[item.Name]
+		if !ok {
+			if count, counted := counts[item.Name]; counted {
+				value, ok = count, true
+			}
+		}
+
+		if item.LengthField != "" {
+			if err := e.checkCount(item, values, counts, itemPath); err != nil {
+				return err
+			}
+		}
+		if err := e.item(item, value, ok, itemPath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkCount verifies that an explicit length field matches the number of
+// elements of the array it counts
+func (e *encoder) checkCount(item *model.Item, values map[string]any, counts map[string]int, path string) error {
+	explicit, ok := values[item.LengthField]
+	if !ok {
+		return nil
+	}
+	count, err := toInt64(explicit)
+	if err != nil {
+		return fmt.Errorf("%s: length field %s: %w", path, item.LengthField, err)
+	}
+	if int(count) != counts[item.LengthField] {
+		return fmt.Errorf("%s: %d elements but %s is %d", path, counts[item.LengthField], item.LengthField, count)
+	}
+	return nil
+}
+
+// item encodes an item, which may be an array; present is false when the
+// value was not supplied and the default should be encoded
+func (e *encoder) item(item *model.Item, value any, present bool, path string) error {
+	if !item.IsArray {
+		return e.element(item, value, present, path)
+	}
+
+	var elements []any
+	if present {
+		list, ok := value.([]any)
+		if !ok {
+			return fmt.Errorf("%s: expected an array, got %T", path, value)
+		}
+		elements = list
+	}
+
+	if item.IsVariableArray() {
+		if len(elements) > item.MaxLength {
+			return fmt.Errorf("%s: %d elements exceed the maximum of %d", path, len(elements), item.MaxLength)
+		}
+		if item.LengthField == "" {
+			e.primitive(item.LengthType, item.ByteOrder, uint64(len(elements)))
+		}
+	} else if present && len(elements) != item.Length {
+		return fmt.Errorf("%s: expected %d elements, got %d", path, item.Length, len(elements))
+	}
+
+	count := len(elements)
+	if !item.IsVariableArray() {
+		count = item.Length
+	}
+	for i := 0; i < count; i++ {
+		var element any
+		if i < len(elements) {
+			element = elements[i]
+		}
+		if err := e.element(item, element, i < len(elements), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// element encodes a single element of an item
+func (e *encoder) element(item *model.Item, value any, present bool, path string) error {
+	switch item.Type {
+	case "container":
+		var fields map[string]any
+		if present {
+			m, ok := value.(map[string]any)
+			if !ok {
+				return fmt.Errorf("%s: expected an object, got %T", path, value)
+			}
+			fields = m
+		}
+		return e.container(e.config.Container(item.Container), fields, path)
+	case "string":
+		var s string
+		if present {
+			str, ok := value.(string)
+			if !ok {
+				return fmt.Errorf("%s: expected a string, got %T", path, value)
+			}
+			s = str
+		}
+		return e.string(item, s, path)
+	case "enum":
+		enum := e.config.Enum(item.Enum)
+		var raw int64
+		if len(enum.Values) > 0 {
+			raw = int64(enum.Values[0].Value)
+		}
+		if present {
+			r, err := enumValue(enum, value)
+			if err != nil {
+				return fmt.Errorf("%s: %w", path, err)
+			}
+			raw = r
+		}
+		return e.integer(enum.Type, item.ByteOrder, raw, path)
+	case "bitfield":
+		var raw uint64
+		if present {
+			r, err := bitfieldValue(item, value)
+			if err != nil {
+				return fmt.Errorf("%s: %w", path, err)
+			}
+			raw = r
+		}
+		return e.integer(item.BaseType, item.ByteOrder, int64(raw), path)
+	case "bool":
+		b := false
+		if present {
+			v, ok := value.(bool)
+			if !ok {
+				return fmt.Errorf("%s: expected a bool, got %T", path, value)
+			}
+			b = v
+		}
+		if b {
+			e.primitive("bool", item.ByteOrder, 1)
+		} else {
+			e.primitive("bool", item.ByteOrder, 0)
+		}
+		return nil
+	case "float", "double":
+		var f float64
+		if present {
+			v, err := toFloat64(value)
+			if err != nil {
+				return fmt.Errorf("%s: %w", path, err)
+			}
+			f = v
+		}
+		if item.Type == "float" {
+			e.primitive("float", item.ByteOrder, uint64(math.Float32bits(float32(f))))
+		} else {
+			e.primitive("double", item.ByteOrder, math.Float64bits(f))
+		}
+		return nil
+	default:
+		if !present {
+			e.primitive(item.Type, item.ByteOrder, 0)
+			return nil
+		}
+		if item.Type == "uint64" {
+			v, err := toUint64(value)
+			if err != nil {
+				return fmt.Errorf("%s: %w", path, err)
+			}
+			e.primitive(item.Type, item.ByteOrder, v)
+			return nil
+		}
+		v, err := toInt64(value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		return e.integer(item.Type, item.ByteOrder, v, path)
+	}
+}
+
+// string encodes a fixed-capacity or length-prefixed string
+func (e *encoder) string(item *model.Item, s string, path string) error {
+	if len(s) > item.MaxLength {
+		return fmt.Errorf("%s: length %d exceeds the maximum of %d", path, len(s), item.MaxLength)
+	}
+	if item.Encoding == "prefixed" {
+		e.primitive(item.LengthType, item.ByteOrder, uint64(len(s)))
+		e.buf = append(e.buf, s...)
+		return nil
+	}
+	e.buf = append(e.buf, s...)
+	e.buf = append(e.buf, make([]byte, item.MaxLength-len(s))...)
+	return nil
+}
+
+// integer range-checks an integer against its type and encodes it
+func (e *encoder) integer(itemType, byteOrder string, v int64, path string) error {
+	var lo, hi int64
+	switch itemType {
+	case "uint8":
+		lo, hi = 0, math.MaxUint8
+	case "uint16":
+		lo, hi = 0, math.MaxUint16
+	case "uint32":
+		lo, hi = 0, math.MaxUint32
+	case "int8":
+		lo, hi = math.MinInt8, math.MaxInt8
+	case "int16":
+		lo, hi = math.MinInt16, math.MaxInt16
+	case "int32":
+		lo, hi = math.MinInt32, math.MaxInt32
+	default:
+		lo, hi = math.MinInt64, math.MaxInt64
+	}
+	if v < lo || v > hi {
+		return fmt.Errorf("%s: %d is out of range for %s", path, v, itemType)
+	}
+	e.primitive(itemType, byteOrder, uint64(v))
+	return nil
+}
+
+// primitive appends the low bytes of bits in the size of a primitive type
+func (e *encoder) primitive(itemType, byteOrder string, bits uint64) {
+	var order binary.AppendByteOrder = binary.LittleEndian
+	if byteOrder == "big" {
+		order = binary.BigEndian
+	}
+	switch PrimitiveSize(itemType) {
+	case 1:
+		e.buf = append(e.buf, byte(bits))
+	case 2:
+		e.buf = order.AppendUint16(e.buf, uint16(bits))
+	case 4:
+		e.buf = order.AppendUint32(e.buf, uint32(bits))
+	case 8:
+		e.buf = order.AppendUint64(e.buf, bits)
+	}
+}
+
+// enumValue resolves an enum value given by name or number
+func enumValue(enum *model.Enum, value any) (int64, error) {
+	if name, ok := value.(string); ok {
+		for _, v := range enum.Values {
+			if v.Name == name {
+				return int64(v.Value), nil
+			}
+		}
+		return 0, fmt.Errorf("%q is not a member of %s", name, enum.Name)
+	}
+	raw, err := toInt64(value)
+	if err != nil {
+		return 0, err
+	}
+	for _, v := range enum.Values {
+		if int64(v.Value) == raw {
+			return raw, nil
+		}
+	}
+	return 0, fmt.Errorf("%d is not a member of %s", raw, enum.Name)
+}
+
+// bitfieldValue packs a bitfield given as a raw number or as an object of
+// bit names
+func bitfieldValue(item *model.Item, value any) (uint64, error) {
+	bits, ok := value.(map[string]any)
+	if !ok {
+		return toUint64(value)
+	}
+
+	var raw uint64
+	for name, v := range bits {
+		var bit *model.Bit
+		for i := range item.Bits {
+			if item.Bits[i].Name == name {
+				bit = &item.Bits[i]
+			}
+		}
+		if bit == nil {
+			return 0, fmt.Errorf("unknown bit %q", name)
+		}
+
+		var field uint64
+		if b, ok := v.(bool); ok {
+			if b {
+				field = 1
+			}
+		} else {
+			f, err := toUint64(v)
+			if err != nil {
+				return 0, fmt.Errorf("bit %s: %w", name, err)
+			}
+			field = f
+		}
+		if field > bitMask(*bit) {
+			return 0, fmt.Errorf("bit %s: %d does not fit in %d bits", name, field, bit.Width)
+		}
+		raw |= field << uint(bit.Offset)
+	}
+	return raw, nil
+}
+
+// toInt64 converts a JSON or Go number to an int64
+func toInt64(value any) (int64, error) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), nil
+	case int8:
+		return int64(v), nil
+	case int16:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case uint8:
+		return int64(v), nil
+	case uint16:
+		return int64(v), nil
+	case uint32:
+		return int64(v), nil
+	case uint64:
+		if v > math.MaxInt64 {
+			return 0, fmt.Errorf("%d is out of range", v)
+		}
+		return int64(v), nil
+	case float64:
+		if v != math.Trunc(v) || v < math.MinInt64 || v >= math.MaxInt64 {
+			return 0, fmt.Errorf("%v is not an integer", v)
+		}
+		return int64(v), nil
+	case json.Number:
+		return v.Int64()
+	default:
+		return 0, fmt.Errorf("expected an integer, got %T", value)
+	}
+}
+
+// toUint64 converts a JSON or Go number to a uint64
+func toUint64(value any) (uint64, error) {
+	switch v := value.(type) {
+	case uint64:
+		return v, nil
+	case float64:
+		if v != math.Trunc(v) || v < 0 || v >= math.MaxUint64 {
+			return 0, fmt.Errorf("%v is not an unsigned integer", v)
+		}
+		return uint64(v), nil
+	case json.Number:
+		var u uint64
+		if _, err := fmt.Sscan(v.String(), &u); err != nil {
+			return 0, fmt.Errorf("%s is not an unsigned integer", v)
+		}
+		return u, nil
+	default:
+		i, err := toInt64(value)
+		if err != nil {
+			return 0, err
+		}
+		if i < 0 {
+			return 0, fmt.Errorf("%d is negative", i)
+		}
+		return uint64(i), nil
+	}
+}
+
+// toFloat64 converts a JSON or Go number to a float64
+func toFloat64(value any) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case float32:
+		return float64(v), nil
+	case json.Number:
+		return v.Float64()
+	case string:
+		// Non-finite floats are written as strings such as "NaN" or "+Inf"
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return 0, fmt.Errorf("expected a number, got %q", v)
+		}
+		return f, nil
+	default:
+		i, err := toInt64(value)
+		if err != nil {
+			return 0, fmt.Errorf("expected a number, got %T", value)
+		}
+		return float64(i), nil
+	}
+}
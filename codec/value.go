@@ -0,0 +1,202 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strconv"
+
+	"github.com/sammyjroberts/uscdl/model"
+)
+
+// Kind identifies the shape of a decoded value
+type Kind int
+
+const (
+	// KindContainer is a container holding Fields
+	KindContainer Kind = iota
+	// KindArray is an array holding Elements
+	KindArray
+	// KindPrimitive is a number or bool held in Raw
+	KindPrimitive
+	// KindString is a string held in Raw
+	KindString
+	// KindEnum is an enum whose integer value is held in Raw
+	KindEnum
+	// KindBitfield is a bitfield whose integer value is held in Raw
+	KindBitfield
+)
+
+// Value is a node of a decoded value tree
+type Value struct {
+	// Name is the item name, an indexed name such as "x[2]" for array
+	// elements, or the container name at the root
+	Name string
+	// Kind is the shape of the value
+	Kind Kind
+	// Item is the item the value was decoded from, nil at the root
+	Item *model.Item
+	// Enum is the enumeration of a KindEnum value
+	Enum *model.Enum
+	// Offset and Size locate the value's bytes in the payload
+	Offset int
+	Size   int
+	// Raw holds uint64, int64, float64, bool or string for primitive, string,
+	// enum and bitfield values
+	Raw any
+	// Fields holds the items of a container value
+	Fields []*Value
+	// Elements holds the elements of an array value
+	Elements []*Value
+}
+
+// Interface converts the value to plain Go values: map[string]any for
+// containers, []any for arrays, the enum value name for known enum values,
+// a map of bit names for bitfields, and Raw otherwise
+func (v *Value) Interface() any {
+	switch v.Kind {
+	case KindContainer:
+		fields := make(map[string]any, len(v.Fields))
+		for _, field := range v.Fields {
+			fields[field.Name] = field.Interface()
+		}
+		return fields
+	case KindArray:
+		elements := make([]any, len(v.Elements))
+		for i, element := range v.Elements {
+			elements[i] = element.Interface()
+		}
+		return elements
+	case KindEnum:
+		if name := v.EnumName(); name != "" {
+			return name
+		}
+		return v.Raw
+	case KindBitfield:
+		raw := v.Raw.(uint64)
+		bits := make(map[string]any, len(v.Item.Bits))
+		for _, bit := range v.Item.Bits {
+			field := (raw >> uint(bit.Offset)) & bitMask(bit)
+			if bit.Width <= 1 {
+				bits[bit.Name] = field != 0
+			} else {
+				bits[bit.Name] = field
+			}
+		}
+		return bits
+	default:
+		return v.Raw
+	}
+}
+
+// MarshalJSON encodes the value like Interface but keeps container items and
+// bitfield bits in declaration order
+func (v *Value) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	switch v.Kind {
+	case KindContainer:
+		buf.WriteByte('{')
+		for i, field := range v.Fields {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeJSONMember(&buf, field.Name, field); err != nil {
+				return nil, err
+			}
+		}
+		buf.WriteByte('}')
+	case KindArray:
+		buf.WriteByte('[')
+		for i, element := range v.Elements {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			b, err := element.MarshalJSON()
+			if err != nil {
+				return nil, err
+			}
+			buf.Write(b)
+		}
+		buf.WriteByte(']')
+	case KindBitfield:
+		bits := v.Interface().(map[string]any)
+		buf.WriteByte('{')
+		for i, bit := range v.Item.Bits {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeJSONMember(&buf, bit.Name, bits[bit.Name]); err != nil {
+				return nil, err
+			}
+		}
+		buf.WriteByte('}')
+	default:
+		// JSON has no NaN or infinity, so non-finite floats become strings
+		if f, ok := v.Raw.(float64); ok && (math.IsNaN(f) || math.IsInf(f, 0)) {
+			return json.Marshal(strconv.FormatFloat(f, 'g', -1, 64))
+		}
+		return json.Marshal(v.Interface())
+	}
+	return buf.Bytes(), nil
+}
+
+// writeJSONMember writes a "name":value object member
+func writeJSONMember(buf *bytes.Buffer, name string, value any) error {
+	key, err := json.Marshal(name)
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	buf.Write(key)
+	buf.WriteByte(':')
+	buf.Write(b)
+	return nil
+}
+
+// EnumName returns the name of a KindEnum value, or "" if the value is not a
+// member of its enumeration
+func (v *Value) EnumName() string {
+	if v.Kind != KindEnum || v.Enum == nil {
+		return ""
+	}
+	raw := toInt64Raw(v.Raw)
+	for _, value := range v.Enum.Values {
+		if int64(value.Value) == raw {
+			return value.Name
+		}
+	}
+	return ""
+}
+
+// Field returns the field of a container value with the given name, or nil
+func (v *Value) Field(name string) *Value {
+	for _, field := range v.Fields {
+		if field.Name == name {
+			return field
+		}
+	}
+	return nil
+}
+
+// bitMask returns the unshifted mask covering a bit's width
+func bitMask(bit model.Bit) uint64 {
+	width := bit.Width
+	if width < 1 {
+		width = 1
+	}
+	return (uint64(1) << uint(width)) - 1
+}
+
+// toInt64Raw converts an integer Raw value to int64
+func toInt64Raw(raw any) int64 {
+	switch r := raw.(type) {
+	case int64:
+		return r
+	case uint64:
+		return int64(r)
+	}
+	return 0
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/sammyjroberts/uscdl/compat"
+	"github.com/sammyjroberts/uscdl/model"
+)
+
+// errBreaking marks a comparison that found breaking changes, which exits
+// with its own status so that CI can tell it from a config that failed to load
+var errBreaking = errors.New("breaking changes")
+
+// runCompat implements the compat subcommand, failing when the wire layout
+// of the new config breaks compatibility with the old one
+func runCompat(args []string) error {
+	flags := newFlagSet("compat", "<old.json> <new.json>",
+		"Exits with status 3 when any change is breaking, 1 when a config fails to",
+		"load and 2 on invalid arguments")
+	schemaFile := flags.String("schema", "schema.json", "JSON Schema used to validate the configs")
+	format := flags.String("format", "text", "output format: text or json")
+	breakingOnly := flags.Bool("breaking", false, "report breaking changes only")
+	if err := parseFlags(flags, args, 2, 2); err != nil {
+		return err
+	}
+	if *format != "text" && *format != "json" {
+		return fmt.Errorf("%w: unknown format %q", errUsage, *format)
+	}
+
+	oldConfig, err := model.Load(flags.Arg(0), *schemaFile)
+	if err != nil {
+		return fmt.Errorf("failed to load old config: %w", err)
+	}
+	newConfig, err := model.Load(flags.Arg(1), *schemaFile)
+	if err != nil {
+		return fmt.Errorf("failed to load new config: %w", err)
+	}
+
+	changes := compat.Compare(oldConfig, newConfig)
+	reported := make([]compat.Change, 0, len(changes))
+	breaking := 0
+	for _, change := range changes {
+		if change.Kind == compat.Breaking {
+			breaking++
+		} else if *breakingOnly {
+			continue
+		}
+		reported = append(reported, change)
+	}
+
+	if *format == "json" {
+		out, err := json.MarshalIndent(reported, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(out))
+	} else {
+		for _, change := range reported {
+			fmt.Println(change)
+		}
+		if len(changes) == 0 {
+			fmt.Println("no changes")
+		}
+	}
+
+	if breaking > 0 {
+		return fmt.Errorf("%d %w", breaking, errBreaking)
+	}
+	return nil
+}
@@ -0,0 +1,335 @@
+// Package compat compares two versions of a uscdl config and classifies the
+// differences by whether they change the wire layout.
+package compat
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/sammyjroberts/uscdl/model"
+)
+
+// Kinds of change
+const (
+	// Breaking changes alter the wire layout, so peers built from the old
+	// config can no longer exchange data with peers built from the new one
+	Breaking = "breaking"
+	// Compatible changes keep every existing byte where it was
+	Compatible = "compatible"
+)
+
+// Change is one difference between two versions of a config
+type Change struct {
+	Kind string `json:"kind"`
+	// Path names the changed definition, such as Container.item or Enum.VALUE
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// String formats the change as kind: path: message
+func (c Change) String() string {
+	return fmt.Sprintf("%s: %s: %s", c.Kind, c.Path, c.Message)
+}
+
+// HasBreaking reports whether any change is breaking
+func HasBreaking(changes []Change) bool {
+	for _, c := range changes {
+		if c.Kind == Breaking {
+			return true
+		}
+	}
+	return false
+}
+
+// comparison collects the changes between two configs
+type comparison struct {
+	old, new *model.Config
+	changes  []Change
+	// grown holds the containers whose only layout change is items appended
+	// at the end
+	grown map[string]bool
+}
+
+// Compare returns the changes from old to new, containers first in the order
+// of the new config, then enums
+func Compare(old, new *model.Config) []Change {
+	c := &comparison{old: old, new: new, grown: make(map[string]bool)}
+
+	for _, container := range old.Containers {
+		if new.Container(container.Name) == nil {
+			c.add(Breaking, container.Name, "container removed")
+		}
+	}
+	for i := range new.Containers {
+		container := &new.Containers[i]
+		previous := old.Container(container.Name)
+		if previous == nil {
+			c.add(Compatible, container.Name, "container added")
+			continue
+		}
+		c.compareContainer(previous, container)
+	}
+	c.checkGrownNesting()
+
+	for _, enum := range old.Enums {
+		if new.Enum(enum.Name) == nil {
+			c.add(Breaking, enum.Name, "enum removed")
+		}
+	}
+	for i := range new.Enums {
+		enum := &new.Enums[i]
+		if previous := old.Enum(enum.Name); previous != nil {
+			c.compareEnum(previous, enum)
+		} else {
+			c.add(Compatible, enum.Name, "enum added")
+		}
+	}
+	return c.changes
+}
+
+// add records a change
+func (c *comparison) add(kind, path, format string, args ...interface{}) {
+	c.changes = append(c.changes, Change{Kind: kind, Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// compareContainer compares the items of two versions of a container
+func (c *comparison) compareContainer(old, new *model.Container) {
+	if old.Description != new.Description {
+		c.add(Compatible, new.Name, "description changed")
+	}
+
+	// Positions in the old container of the items kept in the new one
+	var kept []int
+	for _, item := range old.Items {
+		if new.Item(item.Name) == nil {
+			c.add(Breaking, new.Name+"."+item.Name, "item removed")
+		}
+	}
+
+	lastKept := -1
+	for i := range new.Items {
+		if old.Item(new.Items[i].Name) != nil {
+			lastKept = i
+		}
+	}
+	for i := range new.Items {
+		item := &new.Items[i]
+		path := new.Name + "." + item.Name
+		previous := old.Item(item.Name)
+		if previous == nil {
+			if i < lastKept {
+				c.add(Breaking, path, "item inserted before existing items")
+			} else {
+				c.add(Compatible, path, "item appended")
+				c.grown[new.Name] = true
+			}
+			continue
+		}
+		kept = append(kept, indexOf(old, item.Name))
+		c.compareItem(path, previous, item)
+	}
+	if !sort.IntsAreSorted(kept) {
+		c.add(Breaking, new.Name, "items reordered")
+	}
+}
+
+// compareItem compares two versions of an item
+func (c *comparison) compareItem(path string, old, new *model.Item) {
+	if old.Type != new.Type {
+		c.add(Breaking, path, "type changed from %s to %s", old.Type, new.Type)
+		return
+	}
+
+	switch new.Type {
+	case "enum":
+		if old.Enum != new.Enum {
+			c.add(Breaking, path, "enum changed from %s to %s", old.Enum, new.Enum)
+		}
+	case "container":
+		if old.Container != new.Container {
+			c.add(Breaking, path, "container changed from %s to %s", old.Container, new.Container)
+		}
+	case "bitfield":
+		if old.BaseType != new.BaseType {
+			c.add(Breaking, path, "base type changed from %s to %s", old.BaseType, new.BaseType)
+		}
+		c.compareBits(path, old, new)
+	case "string":
+		if old.Encoding != new.Encoding {
+			c.add(Breaking, path, "string encoding changed from %s to %s", old.Encoding, new.Encoding)
+		}
+	}
+
+	if old.IsArray != new.IsArray {
+		c.add(Breaking, path, "isArray changed from %t to %t", old.IsArray, new.IsArray)
+	} else if new.IsArray && old.Length != new.Length {
+		c.add(Breaking, path, "array length changed from %d to %d", old.Length, new.Length)
+	}
+	if old.MaxLength != new.MaxLength && (new.IsArray || new.Type == "string") {
+		c.add(Breaking, path, "maxLength changed from %d to %d", old.MaxLength, new.MaxLength)
+	}
+	if old.LengthType != new.LengthType && hasLengthPrefix(new) {
+		c.add(Breaking, path, "lengthType changed from %s to %s", old.LengthType, new.LengthType)
+	}
+	if old.LengthField != new.LengthField {
+		c.add(Breaking, path, "lengthField changed from %q to %q", old.LengthField, new.LengthField)
+	}
+	if byteOrder(old) != byteOrder(new) && c.orderMatters(new) {
+		c.add(Breaking, path, "byteOrder changed from %s to %s", byteOrder(old), byteOrder(new))
+	}
+
+	if old.Description != new.Description {
+		c.add(Compatible, path, "description changed")
+	}
+	if old.Units != new.Units {
+		c.add(Compatible, path, "units changed from %q to %q", old.Units, new.Units)
+	}
+}
+
+// compareBits compares the bits of two versions of a bitfield
+func (c *comparison) compareBits(path string, old, new *model.Item) {
+	for _, bit := range old.Bits {
+		if findBit(new, bit.Name) == nil {
+			c.add(Breaking, path+"."+bit.Name, "bit removed")
+		}
+	}
+	for _, bit := range new.Bits {
+		previous := findBit(old, bit.Name)
+		switch {
+		case previous == nil:
+			c.add(Compatible, path+"."+bit.Name, "bit added")
+		case previous.Offset != bit.Offset || previous.Width != bit.Width:
+			c.add(Breaking, path+"."+bit.Name, "bit moved from offset %d width %d to offset %d width %d",
+				previous.Offset, previous.Width, bit.Offset, bit.Width)
+		case previous.Description != bit.Description:
+			c.add(Compatible, path+"."+bit.Name, "description changed")
+		}
+	}
+}
+
+// compareEnum compares two versions of an enum
+func (c *comparison) compareEnum(old, new *model.Enum) {
+	if old.Type != new.Type {
+		c.add(Breaking, new.Name, "type changed from %s to %s", old.Type, new.Type)
+	}
+	if old.Description != new.Description {
+		c.add(Compatible, new.Name, "description changed")
+	}
+	for _, value := range old.Values {
+		if findValue(new, value.Name) == nil {
+			c.add(Breaking, new.Name+"."+value.Name, "value removed")
+		}
+	}
+	for _, value := range new.Values {
+		path := new.Name + "." + value.Name
+		previous := findValue(old, value.Name)
+		switch {
+		case previous == nil:
+			c.add(Compatible, path, "value %d added", value.Value)
+		case previous.Value != value.Value:
+			c.add(Breaking, path, "value changed from %d to %d", previous.Value, value.Value)
+		case previous.Description != value.Description:
+			c.add(Compatible, path, "description changed")
+		}
+	}
+}
+
+// checkGrownNesting reports containers that only had items appended but are
+// nested in other containers, where the extra bytes shift what follows. A
+// container nested as the last item of its parent only grows the parent,
+// which is reported in turn.
+func (c *comparison) checkGrownNesting() {
+	for len(c.grown) > 0 {
+		next := make(map[string]bool)
+		names := make([]string, 0, len(c.grown))
+		for name := range c.grown {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			for ci := range c.new.Containers {
+				parent := &c.new.Containers[ci]
+				for i, item := range parent.Items {
+					if item.Type != "container" || item.Container != name {
+						continue
+					}
+					if previous := c.old.Container(parent.Name); previous == nil || previous.Item(item.Name) == nil {
+						continue
+					}
+					path := parent.Name + "." + item.Name
+					if i == len(parent.Items)-1 && !item.IsArray {
+						c.add(Compatible, path, "nested container %s grew at the end of %s", name, parent.Name)
+						next[parent.Name] = true
+					} else {
+						c.add(Breaking, path, "nested container %s grew, shifting the items after it", name)
+					}
+				}
+			}
+		}
+		c.grown = next
+	}
+}
+
+// indexOf returns the position of the named item in a container
+func indexOf(container *model.Container, name string) int {
+	for i := range container.Items {
+		if container.Items[i].Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// findBit returns the named bit of a bitfield item, or nil
+func findBit(item *model.Item, name string) *model.Bit {
+	for i := range item.Bits {
+		if item.Bits[i].Name == name {
+			return &item.Bits[i]
+		}
+	}
+	return nil
+}
+
+// findValue returns the named value of an enum, or nil
+func findValue(enum *model.Enum, name string) *model.EnumValue {
+	for i := range enum.Values {
+		if enum.Values[i].Name == name {
+			return &enum.Values[i]
+		}
+	}
+	return nil
+}
+
+// hasLengthPrefix reports whether an item is encoded after a count of its own
+func hasLengthPrefix(item *model.Item) bool {
+	return item.IsVariableArray() && item.LengthField == "" ||
+		item.Type == "string" && item.Encoding == "prefixed"
+}
+
+// orderMatters reports whether an item puts any multi-byte value on the wire,
+// so that flipping its byte order changes the encoding
+func (c *comparison) orderMatters(item *model.Item) bool {
+	if hasLengthPrefix(item) && model.PrimitiveSize(item.LengthType) > 1 {
+		return true
+	}
+	switch item.Type {
+	case "container", "string":
+		return false
+	case "enum":
+		enum := c.new.Enum(item.Enum)
+		return enum != nil && model.PrimitiveSize(enum.Type) > 1
+	case "bitfield":
+		return model.PrimitiveSize(item.BaseType) > 1
+	default:
+		return model.PrimitiveSize(item.Type) > 1
+	}
+}
+
+// byteOrder returns the effective byte order of an item
+func byteOrder(item *model.Item) string {
+	if item.ByteOrder == "big" {
+		return "big"
+	}
+	return "little"
+}
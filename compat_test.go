@@ -0,0 +1,45 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// compatConfig is a config whose single item has the given type
+const compatConfig = `{"containers": [{"name": "Sample", "description": "sample",
+	"items": [{"name": "value", "type": "ITEM_TYPE", "description": "value"}]}]}`
+
+// writeCompatConfig writes compatConfig with an item type and returns its path
+func writeCompatConfig(t *testing.T, name, itemType string) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(file, []byte(strings.ReplaceAll(compatConfig, "ITEM_TYPE", itemType)), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func TestCompatExitCodes(t *testing.T) {
+	old := writeCompatConfig(t, "old.json", "uint16")
+	same := writeCompatConfig(t, "same.json", "uint16")
+	wider := writeCompatConfig(t, "wider.json", "uint32")
+	missing := filepath.Join(t.TempDir(), "missing.json")
+
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"no changes", []string{"compat", old, same}, 0},
+		{"config fails to load", []string{"compat", old, missing}, 1},
+		{"invalid arguments", []string{"compat", old}, 2},
+		{"breaking change", []string{"compat", old, wider}, 3},
+	}
+	for _, test := range tests {
+		if got := run(test.args); got != test.want {
+			t.Errorf("%s: exit code %d, want %d", test.name, got, test.want)
+		}
+	}
+}
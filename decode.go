@@ -0,0 +1,137 @@
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/sammyjroberts/uscdl/codec"
+	"github.com/sammyjroberts/uscdl/model"
+)
+
+// runDecode implements the decode subcommand, printing a captured frame as
+// JSON or as a table with units
+func runDecode(args []string) error {
+	flags := newFlagSet("decode", "<config.json> <container> [input]",
+		"Reads the frame from input, or from stdin when input is omitted or -")
+	schemaFile := flags.String("schema", "schema.json", "JSON Schema used to validate the config")
+	hexInput := flags.Bool("hex", false, "input is hex text rather than raw binary")
+	format := flags.String("format", "json", "output format: json or table")
+	if err := parseFlags(flags, args, 2, 3); err != nil {
+		return err
+	}
+	if *format != "json" && *format != "table" {
+		return fmt.Errorf("%w: unknown format %q", errUsage, *format)
+	}
+
+	config, err := model.Load(flags.Arg(0), *schemaFile)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	data, err := readInput(flags.Arg(2), *hexInput)
+	if err != nil {
+		return err
+	}
+
+	value, err := codec.New(config).DecodeValue(flags.Arg(1), data)
+	if err != nil {
+		return err
+	}
+	if trailing := len(data) - value.Size; trailing > 0 {
+		fmt.Fprintf(os.Stderr, "warning: ignoring %d trailing bytes\n", trailing)
+	}
+
+	if *format == "table" {
+		return writeValueTable(os.Stdout, value)
+	}
+	out, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+// readInput reads a frame from a file, or stdin when path is empty or -,
+// decoding hex text when isHex is set
+func readInput(path string, isHex bool) ([]byte, error) {
+	var data []byte
+	var err error
+	if path == "" || path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read input: %w", err)
+	}
+	if !isHex {
+		return data, nil
+	}
+
+	// Accept whitespace-separated bytes and 0x prefixes as hexdump tools
+	// commonly print them
+	text := strings.Join(strings.Fields(string(data)), "")
+	text = strings.ReplaceAll(strings.ReplaceAll(text, "0x", ""), "0X", "")
+	decoded, err := hex.DecodeString(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse hex input: %w", err)
+	}
+	return decoded, nil
+}
+
+// writeValueTable prints one row per decoded leaf value with its offset,
+// size and units
+func writeValueTable(w io.Writer, value *codec.Value) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "OFFSET\tSIZE\tITEM\tVALUE\tUNITS")
+	writeValueRows(tw, value, "", "")
+	return tw.Flush()
+}
+
+// writeValueRows prints the rows of a value and its children. Items of a
+// nested container without units of their own inherit the container item's.
+func writeValueRows(w io.Writer, value *codec.Value, prefix, units string) {
+	name := prefix + value.Name
+	if value.Item != nil && value.Item.Units != "" {
+		units = value.Item.Units
+	}
+
+	switch value.Kind {
+	case codec.KindContainer:
+		// The root container's name is not part of item paths
+		if value.Item == nil {
+			name = ""
+		} else {
+			name += "."
+		}
+		for _, field := range value.Fields {
+			writeValueRows(w, field, name, units)
+		}
+	case codec.KindArray:
+		for _, element := range value.Elements {
+			writeValueRows(w, element, prefix, units)
+		}
+	case codec.KindEnum:
+		text := fmt.Sprint(value.Raw)
+		if enumName := value.EnumName(); enumName != "" {
+			text = fmt.Sprintf("%s (%v)", enumName, value.Raw)
+		}
+		fmt.Fprintf(w, "%d\t%d\t%s\t%s\t%s\n", value.Offset, value.Size, name, text, units)
+	case codec.KindBitfield:
+		fmt.Fprintf(w, "%d\t%d\t%s\t0x%0*X\t%s\n", value.Offset, value.Size, name, value.Size*2, value.Raw, units)
+		bits := value.Interface().(map[string]any)
+		for _, bit := range value.Item.Bits {
+			fmt.Fprintf(w, "\t\t%s.%s\t%v\t\n", name, bit.Name, bits[bit.Name])
+		}
+	case codec.KindString:
+		fmt.Fprintf(w, "%d\t%d\t%s\t%q\t%s\n", value.Offset, value.Size, name, value.Raw, units)
+	default:
+		fmt.Fprintf(w, "%d\t%d\t%s\t%v\t%s\n", value.Offset, value.Size, name, value.Raw, units)
+	}
+}
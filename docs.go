@@ -0,0 +1,52 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/sammyjroberts/uscdl/generate"
+	"github.com/sammyjroberts/uscdl/model"
+	"github.com/sammyjroberts/uscdl/templates"
+)
+
+// runDocs implements the docs subcommand, rendering Markdown reference
+// documentation of a config's enums and container layouts
+func runDocs(args []string) error {
+	flags := newFlagSet("docs", "<config.json>")
+	schemaFile := flags.String("schema", "schema.json", "JSON Schema used to validate the config")
+	outputFile := flags.String("o", "", "write the documentation to a file instead of stdout")
+	templateDir := flags.String("templates", "", "directory of .tmpl files that replace or extend the built-in templates")
+	if err := parseFlags(flags, args, 1, 1); err != nil {
+		return err
+	}
+
+	configFile := flags.Arg(0)
+	config, err := model.Load(configFile, *schemaFile)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	tmpl := templates.MarkdownTemplate
+	if *templateDir != "" {
+		overrides, err := templates.LoadOverrides(os.DirFS(*templateDir))
+		if err != nil {
+			return err
+		}
+		if tmpl, err = overrides.Apply(tmpl); err != nil {
+			return err
+		}
+	}
+
+	var out bytes.Buffer
+	module := templates.NewModule(generate.ModuleName(configFile), config)
+	if err := tmpl.Execute(&out, module); err != nil {
+		return fmt.Errorf("failed to render documentation: %w", err)
+	}
+
+	if *outputFile == "" {
+		_, err = os.Stdout.Write(out.Bytes())
+		return err
+	}
+	return os.WriteFile(*outputFile, out.Bytes(), 0644)
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/sammyjroberts/uscdl/codec"
+	"github.com/sammyjroberts/uscdl/model"
+	"github.com/sammyjroberts/uscdl/templates"
+)
+
+// runEncode implements the encode subcommand, turning a JSON document of item
+// values into the exact wire bytes of a container
+func runEncode(args []string) error {
+	flags := newFlagSet("encode", "<config.json> <container> [values.json]",
+		"Reads the values from values.json, or from stdin when it is omitted or -")
+	schemaFile := flags.String("schema", "schema.json", "JSON Schema used to validate the config")
+	format := flags.String("format", "hex", "output format: raw, hex or c")
+	outputFile := flags.String("o", "", "write the output to a file instead of stdout")
+	arrayName := flags.String("name", "", "variable name of the c array (default <container>_frame)")
+	if err := parseFlags(flags, args, 2, 3); err != nil {
+		return err
+	}
+	if *format != "raw" && *format != "hex" && *format != "c" {
+		return fmt.Errorf("%w: unknown format %q", errUsage, *format)
+	}
+
+	config, err := model.Load(flags.Arg(0), *schemaFile)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	input, err := readInput(flags.Arg(2), false)
+	if err != nil {
+		return err
+	}
+
+	// Keep numbers as json.Number so 64-bit integers survive exactly
+	var values map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(input))
+	decoder.UseNumber()
+	if err := decoder.Decode(&values); err != nil {
+		return fmt.Errorf("failed to parse values: %w", err)
+	}
+
+	data, err := codec.New(config).Encode(flags.Arg(1), values)
+	if err != nil {
+		return err
+	}
+
+	var out bytes.Buffer
+	switch *format {
+	case "raw":
+		out.Write(data)
+	case "hex":
+		out.WriteString(hex.EncodeToString(data))
+		out.WriteByte('\n')
+	case "c":
+		name := *arrayName
+		if name == "" {
+			name = templates.ToSnakeCase(flags.Arg(1)) + "_frame"
+		}
+		writeCArray(&out, name, data)
+	}
+
+	if *outputFile == "" {
+		_, err = os.Stdout.Write(out.Bytes())
+		return err
+	}
+	return os.WriteFile(*outputFile, out.Bytes(), 0644)
+}
+
+// writeCArray writes data as a C uint8_t array definition, twelve bytes per
+// line
+func writeCArray(w io.Writer, name string, data []byte) {
+	fmt.Fprintf(w, "static const uint8_t %s[%d] = {\n", name, len(data))
+	for start := 0; start < len(data); start += 12 {
+		end := start + 12
+		if end > len(data) {
+			end = len(data)
+		}
+		line := make([]string, end-start)
+		for i, b := range data[start:end] {
+			line[i] = fmt.Sprintf("0x%02X", b)
+		}
+		fmt.Fprintf(w, "    %s,\n", strings.Join(line, ", "))
+	}
+	fmt.Fprintln(w, "};")
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/sammyjroberts/uscdl/generate"
+	"github.com/sammyjroberts/uscdl/model"
+	"github.com/sammyjroberts/uscdl/templates"
+)
+
+// reportingFS counts the files written to an output directory and lists them
+// when verbose
+type reportingFS struct {
+	dir     generate.DirFS
+	verbose bool
+	count   int
+}
+
+// WriteFile writes a file and reports it
+func (r *reportingFS) WriteFile(name string, data []byte) error {
+	if err := r.dir.WriteFile(name, data); err != nil {
+		return err
+	}
+	r.count++
+	if r.verbose {
+		fmt.Printf("Generated %s\n", filepath.Join(string(r.dir), filepath.FromSlash(name)))
+	}
+	return nil
+}
+
+// runGenerate implements the generate subcommand
+func runGenerate(args []string) error {
+	flags := newFlagSet("generate", "<config.json>")
+	schemaFile := flags.String("schema", "schema.json", "JSON Schema used to validate the config")
+	outputDir := flags.String("o", "generated", "output directory")
+	bundle := flags.String("bundle", "", "write every backend to a .zip or .tar.gz bundle with a manifest instead of the output directory")
+	backendList := flags.String("backends", strings.Join(generate.Backends(), ","), "comma-separated backends to generate: "+strings.Join(generate.Backends(), ", "))
+	naming := flags.String("naming", templates.NamingDefault, "file naming convention: "+strings.Join(templates.NamingConventions, ", "))
+	templateDir := flags.String("templates", "", "directory of .tmpl files that replace or extend the built-in templates")
+	options := backendOptions{}
+	flags.Var(options, "option", "backend option as backend.name=value; may be repeated")
+	verbose := flags.Bool("v", false, "list every generated file")
+	quiet := flags.Bool("q", false, "print nothing on success")
+	if err := parseFlags(flags, args, 1, 1); err != nil {
+		return err
+	}
+
+	backends, err := parseBackends(*backendList)
+	if err != nil {
+		return err
+	}
+	if _, err := templates.FileBase(*naming, "c", ""); err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	if _, ok := generate.ArchiveFormat(*bundle); *bundle != "" && !ok {
+		return fmt.Errorf("%w: bundle %s must end in .zip, .tar.gz or .tgz", errUsage, *bundle)
+	}
+
+	configFile := flags.Arg(0)
+	config, err := model.Load(configFile, *schemaFile)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	opts := generate.Options{
+		Module:  generate.ModuleName(configFile),
+		Naming:  *naming,
+		Backend: options,
+	}
+	if *templateDir != "" {
+		opts.Templates = os.DirFS(*templateDir)
+	}
+	if *bundle != "" {
+		return writeBundle(config, backends, opts, *bundle, *verbose && !*quiet, *quiet)
+	}
+
+	out := &reportingFS{dir: generate.DirFS(*outputDir), verbose: *verbose && !*quiet}
+	if err := generate.Generate(config, backends, out, opts); err != nil {
+		return err
+	}
+	if !*quiet {
+		fmt.Printf("Generated %d files in %s\n", out.count, *outputDir)
+	}
+	return nil
+}
+
+// writeBundle generates the backends into an archive file, in the format
+// given by its extension
+func writeBundle(config *model.Config, backends []string, opts generate.Options, bundle string, verbose, quiet bool) error {
+	files, err := generate.Bundle(config, backends, opts)
+	if err != nil {
+		return err
+	}
+	format, _ := generate.ArchiveFormat(bundle)
+	out, err := os.Create(bundle)
+	if err != nil {
+		return fmt.Errorf("failed to create bundle: %w", err)
+	}
+	if err := files.WriteArchive(out, format); err != nil {
+		out.Close()
+		return fmt.Errorf("failed to write bundle: %w", err)
+	}
+	if err := out.Close(); err != nil {
+		return fmt.Errorf("failed to write bundle: %w", err)
+	}
+
+	if verbose {
+		for _, name := range files.Names() {
+			fmt.Printf("Bundled %s\n", name)
+		}
+	}
+	if !quiet {
+		fmt.Printf("Bundled %d files in %s\n", len(files), bundle)
+	}
+	return nil
+}
+
+// parseBackends parses a comma-separated backend list
+func parseBackends(list string) ([]string, error) {
+	var backends []string
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if _, ok := generate.Lookup(name); !ok {
+			return nil, fmt.Errorf("%w: unknown backend %q", errUsage, name)
+		}
+		backends = append(backends, name)
+	}
+	if len(backends) == 0 {
+		return nil, fmt.Errorf("%w: no backends selected", errUsage)
+	}
+	return backends, nil
+}
+
+// backendOptions collects repeated -option backend.name=value flags
+type backendOptions map[string]map[string]string
+
+// String returns the options in flag syntax
+func (o backendOptions) String() string {
+	var parts []string
+	for backend, options := range o {
+		for name, value := range options {
+			parts = append(parts, backend+"."+name+"="+value)
+		}
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ",")
+}
+
+// Set parses one backend.name=value option
+func (o backendOptions) Set(value string) error {
+	key, optionValue, ok := strings.Cut(value, "=")
+	backend, name, dotted := strings.Cut(key, ".")
+	if !ok || !dotted || backend == "" || name == "" {
+		return fmt.Errorf("expected backend.name=value, got %q", value)
+	}
+	if o[backend] == nil {
+		o[backend] = make(map[string]string)
+	}
+	o[backend][name] = optionValue
+	return nil
+}
@@ -0,0 +1,88 @@
+package generate
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// ArchiveFormats lists the archive formats WriteArchive supports
+var ArchiveFormats = []string{"zip", "tar.gz"}
+
+// archiveTime is the modification time of every archived file, so that
+// archives of the same files are identical byte for byte
+var archiveTime = time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// ArchiveFormat returns the archive format matching the extension of a file
+// name: .zip, or .tar.gz and .tgz
+func ArchiveFormat(name string) (string, bool) {
+	switch {
+	case strings.HasSuffix(name, ".zip"):
+		return "zip", true
+	case strings.HasSuffix(name, ".tar.gz"), strings.HasSuffix(name, ".tgz"):
+		return "tar.gz", true
+	}
+	return "", false
+}
+
+// WriteArchive writes the stored files to w as an archive in one of
+// ArchiveFormats
+func (m MapFS) WriteArchive(w io.Writer, format string) error {
+	switch format {
+	case "zip":
+		return m.WriteZip(w)
+	case "tar.gz":
+		return m.WriteTarGz(w)
+	}
+	return fmt.Errorf("unknown archive format %q", format)
+}
+
+// WriteZip writes the stored files to w as a ZIP archive, in name order
+func (m MapFS) WriteZip(w io.Writer) error {
+	archive := zip.NewWriter(w)
+	for _, name := range m.Names() {
+		file, err := archive.CreateHeader(&zip.FileHeader{
+			Name:     name,
+			Method:   zip.Deflate,
+			Modified: archiveTime,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to add %s to archive: %w", name, err)
+		}
+		if _, err := file.Write(m[name]); err != nil {
+			return fmt.Errorf("failed to add %s to archive: %w", name, err)
+		}
+	}
+	return archive.Close()
+}
+
+// WriteTarGz writes the stored files to w as a gzip-compressed tar archive,
+// in name order
+func (m MapFS) WriteTarGz(w io.Writer) error {
+	compressed := gzip.NewWriter(w)
+	archive := tar.NewWriter(compressed)
+	for _, name := range m.Names() {
+		err := archive.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     name,
+			Size:     int64(len(m[name])),
+			Mode:     0644,
+			ModTime:  archiveTime,
+			Format:   tar.FormatPAX,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to add %s to archive: %w", name, err)
+		}
+		if _, err := archive.Write(m[name]); err != nil {
+			return fmt.Errorf("failed to add %s to archive: %w", name, err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return err
+	}
+	return compressed.Close()
+}
@@ -0,0 +1,140 @@
+package generate
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"text/template"
+
+	"github.com/sammyjroberts/uscdl/model"
+	"github.com/sammyjroberts/uscdl/templates"
+)
+
+// Backend generates the files of one target language. Generate calls Project
+// once, then Enum for every enum and Container for every container of the
+// config, in config order.
+type Backend interface {
+	// Name identifies the backend in backend lists and option keys
+	Name() string
+	// Options describes the backend-specific options, keyed by option name
+	Options() map[string]string
+	// FileBase returns the file name without extension generated for an enum
+	// or container under a naming convention
+	FileBase(convention, name string) (string, error)
+	// Project generates the files that cover the whole config
+	Project(ctx *Context) error
+	// Enum generates the files of one enum
+	Enum(ctx *Context, enum *model.Enum) error
+	// Container generates the files of one container
+	Container(ctx *Context, container *model.Container) error
+}
+
+// Context is passed to a backend's hooks during one generation
+type Context struct {
+	// Config is the validated config being generated
+	Config *model.Config
+	// Module names the package or module the files belong to
+	Module string
+	// Naming is the file naming convention
+	Naming string
+
+	backend   Backend
+	options   map[string]string
+	overrides *templates.Overrides
+	fsys      FS
+}
+
+// Option returns the value of a backend option, or "" when it is not set
+func (c *Context) Option(name string) string {
+	return c.options[name]
+}
+
+// FileBase returns the file name without extension generated for an enum or
+// container by the current backend
+func (c *Context) FileBase(name string) (string, error) {
+	return c.backend.FileBase(c.Naming, name)
+}
+
+// Execute renders a template with the user template overrides and the naming
+// convention applied
+func (c *Context) Execute(tmpl *template.Template, data interface{}) ([]byte, error) {
+	tmpl, err := c.overrides.Apply(tmpl)
+	if err != nil {
+		return nil, err
+	}
+	tmpl, err = templates.WithNaming(tmpl, c.Naming)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Render executes a template and writes the result to the named file
+func (c *Context) Render(name string, tmpl *template.Template, data interface{}) error {
+	content, err := c.Execute(tmpl, data)
+	if err != nil {
+		return fmt.Errorf("failed to render %s: %w", name, err)
+	}
+	return c.WriteFile(name, content)
+}
+
+// WriteFile writes a generated file. Names are slash-separated paths relative
+// to the output root.
+func (c *Context) WriteFile(name string, data []byte) error {
+	return c.fsys.WriteFile(name, data)
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   []Backend
+)
+
+// Register makes a backend available by name. It panics if a backend with the
+// same name is already registered.
+func Register(backend Backend) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	for _, b := range registry {
+		if b.Name() == backend.Name() {
+			panic("generate: Register called twice for backend " + backend.Name())
+		}
+	}
+	registry = append(registry, backend)
+}
+
+// Lookup returns the registered backend with the given name
+func Lookup(name string) (Backend, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	for _, b := range registry {
+		if b.Name() == name {
+			return b, true
+		}
+	}
+	return nil, false
+}
+
+// Backends returns the names of the registered backends in registration order
+func Backends() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	names := make([]string, len(registry))
+	for i, b := range registry {
+		names[i] = b.Name()
+	}
+	return names
+}
+
+// The built-in backends are registered in a fixed order so that the default
+// backend list is stable
+func init() {
+	Register(cBackend{})
+	Register(tsBackend{})
+	Register(goBackend{})
+	Register(pythonBackend{})
+	Register(rustBackend{})
+}
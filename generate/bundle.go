@@ -0,0 +1,89 @@
+package generate
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/sammyjroberts/uscdl/model"
+)
+
+// ManifestName is the name of the manifest in a bundle
+const ManifestName = "manifest.json"
+
+// Manifest describes the contents of a bundle
+type Manifest struct {
+	Module   string   `json:"module"`
+	Backends []string `json:"backends"`
+	// Containers lists the layout fingerprint of every container, as
+	// generated into the code
+	Containers []ManifestContainer `json:"containers"`
+	Files      []ManifestFile      `json:"files"`
+}
+
+// ManifestContainer records the layout fingerprint of a container
+type ManifestContainer struct {
+	Name        string `json:"name"`
+	Fingerprint string `json:"fingerprint"`
+}
+
+// ManifestFile describes one file of a bundle
+type ManifestFile struct {
+	Name    string `json:"name"`
+	Backend string `json:"backend"`
+	Size    int    `json:"size"`
+	SHA256  string `json:"sha256"`
+}
+
+// Bundle generates the named backends of a config, each into a subdirectory
+// named after the backend, and adds a manifest of the files at the root. An
+// empty backend list selects every registered backend.
+func Bundle(config *model.Config, backends []string, opts Options) (MapFS, error) {
+	if len(backends) == 0 {
+		backends = Backends()
+	}
+	if opts.Module == "" {
+		opts.Module = "uscdl"
+	}
+
+	files := MapFS{}
+	manifest := Manifest{
+		Module:     opts.Module,
+		Backends:   backends,
+		Containers: []ManifestContainer{},
+		Files:      []ManifestFile{},
+	}
+	for _, backend := range backends {
+		generated := MapFS{}
+		if err := Generate(config, []string{backend}, generated, opts); err != nil {
+			return nil, err
+		}
+		for _, name := range generated.Names() {
+			data := generated[name]
+			sum := sha256.Sum256(data)
+			path := backend + "/" + name
+			files[path] = data
+			manifest.Files = append(manifest.Files, ManifestFile{
+				Name:    path,
+				Backend: backend,
+				Size:    len(data),
+				SHA256:  hex.EncodeToString(sum[:]),
+			})
+		}
+	}
+	for i := range config.Containers {
+		container := &config.Containers[i]
+		manifest.Containers = append(manifest.Containers, ManifestContainer{
+			Name:        container.Name,
+			Fingerprint: fmt.Sprintf("0x%08X", container.Fingerprint()),
+		})
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	files[ManifestName] = append(data, '\n')
+	return files, nil
+}
@@ -0,0 +1,47 @@
+package generate
+
+import (
+	"github.com/sammyjroberts/uscdl/model"
+	"github.com/sammyjroberts/uscdl/templates"
+)
+
+// cBackend generates a header per enum and a header and source file per
+// container, sharing one byte-order helper header
+type cBackend struct{}
+
+// Name returns "c"
+func (cBackend) Name() string { return "c" }
+
+// Options returns nil; the C backend has no options
+func (cBackend) Options() map[string]string { return nil }
+
+// FileBase returns the C file name of a type
+func (cBackend) FileBase(convention, name string) (string, error) {
+	return templates.FileBase(convention, "c", name)
+}
+
+// Project writes the shared byte-order helpers used by every source file
+func (cBackend) Project(ctx *Context) error {
+	return ctx.Render("uscdl_endian.h", templates.CEndianTemplate, nil)
+}
+
+// Enum writes the enum header
+func (cBackend) Enum(ctx *Context, enum *model.Enum) error {
+	base, err := ctx.FileBase(enum.Name)
+	if err != nil {
+		return err
+	}
+	return ctx.Render(base+".h", templates.CEnumTemplate, enum)
+}
+
+// Container writes the container header and source file
+func (cBackend) Container(ctx *Context, container *model.Container) error {
+	base, err := ctx.FileBase(container.Name)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Render(base+".h", templates.CHeaderTemplate, *container); err != nil {
+		return err
+	}
+	return ctx.Render(base+".c", templates.CSourceTemplate, *container)
+}
@@ -0,0 +1,48 @@
+package generate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// FS receives generated files. Names are slash-separated paths relative to
+// the output root.
+type FS interface {
+	WriteFile(name string, data []byte) error
+}
+
+// DirFS writes generated files below a directory on disk
+type DirFS string
+
+// WriteFile writes a file below the directory, creating parent directories
+func (dir DirFS) WriteFile(name string, data []byte) error {
+	path := filepath.Join(string(dir), filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// MapFS collects generated files in memory, keyed by name
+type MapFS map[string][]byte
+
+// WriteFile stores a copy of the file contents
+func (m MapFS) WriteFile(name string, data []byte) error {
+	m[name] = append([]byte(nil), data...)
+	return nil
+}
+
+// Names returns the names of the stored files in sorted order
+func (m MapFS) Names() []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,128 @@
+// Package generate renders the code of every uscdl backend for a config. It
+// is the library behind the uscdl command and can be embedded in other build
+// tools.
+package generate
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/sammyjroberts/uscdl/model"
+	"github.com/sammyjroberts/uscdl/templates"
+)
+
+// Options control the names of generated files and packages
+type Options struct {
+	// Module names the Go package and the Python and Rust modules. It
+	// defaults to "uscdl".
+	Module string
+	// Naming is the file naming convention, one of
+	// templates.NamingConventions. It defaults to templates.NamingDefault.
+	Naming string
+	// Backend holds backend-specific options keyed by backend name, then by
+	// option name
+	Backend map[string]map[string]string
+	// Templates is a directory of user templates that replace or extend the
+	// built-in ones, as described by templates.Overrides. Nil uses the
+	// built-in templates only.
+	Templates fs.FS
+}
+
+// Generate writes the files of the named backends for a config to fsys. The
+// config must come from model.Load or have passed model.Validate. An empty
+// backend list selects every registered backend.
+func Generate(config *model.Config, backends []string, fsys FS, opts Options) error {
+	if len(backends) == 0 {
+		backends = Backends()
+	}
+	if opts.Module == "" {
+		opts.Module = "uscdl"
+	}
+	if opts.Naming == "" {
+		opts.Naming = templates.NamingDefault
+	}
+	if _, err := templates.FileBase(opts.Naming, "c", ""); err != nil {
+		return err
+	}
+
+	var overrides *templates.Overrides
+	if opts.Templates != nil {
+		o, err := templates.LoadOverrides(opts.Templates)
+		if err != nil {
+			return err
+		}
+		overrides = o
+	}
+
+	selected := make([]Backend, len(backends))
+	for i, name := range backends {
+		backend, ok := Lookup(name)
+		if !ok {
+			return fmt.Errorf("unknown backend %q", name)
+		}
+		selected[i] = backend
+	}
+	for name, options := range opts.Backend {
+		backend, ok := Lookup(name)
+		if !ok {
+			return fmt.Errorf("options given for unknown backend %q", name)
+		}
+		for option := range options {
+			if _, ok := backend.Options()[option]; !ok {
+				return fmt.Errorf("backend %s has no option %q", name, option)
+			}
+		}
+	}
+
+	for _, backend := range selected {
+		ctx := &Context{
+			Config:    config,
+			Module:    opts.Module,
+			Naming:    opts.Naming,
+			backend:   backend,
+			options:   opts.Backend[backend.Name()],
+			overrides: overrides,
+			fsys:      fsys,
+		}
+		if err := generateBackend(ctx, backend); err != nil {
+			return fmt.Errorf("%s backend: %w", backend.Name(), err)
+		}
+	}
+	return nil
+}
+
+// generateBackend runs every hook of a backend over the config
+func generateBackend(ctx *Context, backend Backend) error {
+	if err := backend.Project(ctx); err != nil {
+		return err
+	}
+	for i := range ctx.Config.Enums {
+		if err := backend.Enum(ctx, &ctx.Config.Enums[i]); err != nil {
+			return err
+		}
+	}
+	for i := range ctx.Config.Containers {
+		if err := backend.Container(ctx, &ctx.Config.Containers[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ModuleName derives a Go package and Python module name from the config file
+// name, keeping only lowercase letters and digits
+func ModuleName(configFile string) string {
+	base := strings.TrimSuffix(filepath.Base(configFile), filepath.Ext(configFile))
+	var name strings.Builder
+	for _, r := range strings.ToLower(base) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9' && name.Len() > 0) {
+			name.WriteRune(r)
+		}
+	}
+	if name.Len() == 0 {
+		return "uscdl"
+	}
+	return name.String()
+}
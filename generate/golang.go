@@ -0,0 +1,86 @@
+package generate
+
+import (
+	"fmt"
+	"go/format"
+	"go/token"
+	"path"
+	"text/template"
+
+	"github.com/sammyjroberts/uscdl/model"
+	"github.com/sammyjroberts/uscdl/templates"
+)
+
+// goBackend generates a Go package with a file per enum and container
+type goBackend struct{}
+
+// Name returns "go"
+func (goBackend) Name() string { return "go" }
+
+// Options describes the Go backend options
+func (goBackend) Options() map[string]string {
+	return map[string]string{
+		"package": "Go package name and directory (default the module name)",
+	}
+}
+
+// FileBase returns the Go file name of a type
+func (goBackend) FileBase(convention, name string) (string, error) {
+	return templates.FileBase(convention, "go", name)
+}
+
+// Project does nothing; every file of the package is self-contained
+func (goBackend) Project(ctx *Context) error { return nil }
+
+// Enum writes the enum file
+func (b goBackend) Enum(ctx *Context, enum *model.Enum) error {
+	pkg, err := b.packageName(ctx)
+	if err != nil {
+		return err
+	}
+	data := templates.GoEnumFile{Package: pkg, Enum: enum}
+	return b.write(ctx, pkg, enum.Name, templates.GoEnumTemplate, data)
+}
+
+// Container writes the container file
+func (b goBackend) Container(ctx *Context, container *model.Container) error {
+	pkg, err := b.packageName(ctx)
+	if err != nil {
+		return err
+	}
+	data := templates.GoFile{Package: pkg, Container: *container}
+	return b.write(ctx, pkg, container.Name, templates.GoTemplate, data)
+}
+
+// packageName returns the Go package the files belong to, which also names
+// their directory, so it must be an identifier that is not a keyword
+func (goBackend) packageName(ctx *Context) (string, error) {
+	name := ctx.Option("package")
+	if name == "" {
+		name = ctx.Module
+	}
+	if !token.IsIdentifier(name) || name == "_" {
+		return "", fmt.Errorf("package %q is not a valid Go package name", name)
+	}
+	return name, nil
+}
+
+// write renders a Go template into the package directory and formats the
+// result with gofmt
+func (goBackend) write(ctx *Context, pkg, name string, tmpl *template.Template, data interface{}) error {
+	base, err := ctx.FileBase(name)
+	if err != nil {
+		return err
+	}
+	name = path.Join(pkg, base+".go")
+
+	content, err := ctx.Execute(tmpl, data)
+	if err != nil {
+		return fmt.Errorf("failed to render %s: %w", name, err)
+	}
+	source, err := format.Source(content)
+	if err != nil {
+		return fmt.Errorf("failed to format %s: %w", name, err)
+	}
+	return ctx.WriteFile(name, source)
+}
@@ -0,0 +1,32 @@
+package generate
+
+import (
+	"github.com/sammyjroberts/uscdl/model"
+	"github.com/sammyjroberts/uscdl/templates"
+)
+
+// pythonBackend generates one Python module holding every enum and container
+type pythonBackend struct{}
+
+// Name returns "python"
+func (pythonBackend) Name() string { return "python" }
+
+// Options returns nil; the Python backend has no options
+func (pythonBackend) Options() map[string]string { return nil }
+
+// FileBase returns the module name; every type shares the one module file
+func (pythonBackend) FileBase(convention, name string) (string, error) {
+	return name, nil
+}
+
+// Project writes the module
+func (pythonBackend) Project(ctx *Context) error {
+	module := templates.NewModule(ctx.Module, ctx.Config)
+	return ctx.Render(ctx.Module+".py", templates.PythonTemplate, module)
+}
+
+// Enum does nothing; enums are written by Project
+func (pythonBackend) Enum(ctx *Context, enum *model.Enum) error { return nil }
+
+// Container does nothing; containers are written by Project
+func (pythonBackend) Container(ctx *Context, container *model.Container) error { return nil }
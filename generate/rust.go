@@ -0,0 +1,33 @@
+package generate
+
+import (
+	"github.com/sammyjroberts/uscdl/model"
+	"github.com/sammyjroberts/uscdl/templates"
+)
+
+// rustBackend generates one no_std Rust module holding every enum and
+// container
+type rustBackend struct{}
+
+// Name returns "rust"
+func (rustBackend) Name() string { return "rust" }
+
+// Options returns nil; the Rust backend has no options
+func (rustBackend) Options() map[string]string { return nil }
+
+// FileBase returns the module name; every type shares the one module file
+func (rustBackend) FileBase(convention, name string) (string, error) {
+	return name, nil
+}
+
+// Project writes the module
+func (rustBackend) Project(ctx *Context) error {
+	module := templates.NewModule(ctx.Module, ctx.Config)
+	return ctx.Render(ctx.Module+".rs", templates.RustTemplate, module)
+}
+
+// Enum does nothing; enums are written by Project
+func (rustBackend) Enum(ctx *Context, enum *model.Enum) error { return nil }
+
+// Container does nothing; containers are written by Project
+func (rustBackend) Container(ctx *Context, container *model.Container) error { return nil }
@@ -0,0 +1,41 @@
+package generate
+
+import (
+	"github.com/sammyjroberts/uscdl/model"
+	"github.com/sammyjroberts/uscdl/templates"
+)
+
+// tsBackend generates a TypeScript module per enum and container
+type tsBackend struct{}
+
+// Name returns "ts"
+func (tsBackend) Name() string { return "ts" }
+
+// Options returns nil; the TypeScript backend has no options
+func (tsBackend) Options() map[string]string { return nil }
+
+// FileBase returns the TypeScript file name of a type
+func (tsBackend) FileBase(convention, name string) (string, error) {
+	return templates.FileBase(convention, "ts", name)
+}
+
+// Project does nothing; TypeScript modules import each other directly
+func (tsBackend) Project(ctx *Context) error { return nil }
+
+// Enum writes the enum module
+func (tsBackend) Enum(ctx *Context, enum *model.Enum) error {
+	base, err := ctx.FileBase(enum.Name)
+	if err != nil {
+		return err
+	}
+	return ctx.Render(base+".ts", templates.TypeScriptEnumTemplate, enum)
+}
+
+// Container writes the container module
+func (tsBackend) Container(ctx *Context, container *model.Container) error {
+	base, err := ctx.FileBase(container.Name)
+	if err != nil {
+		return err
+	}
+	return ctx.Render(base+".ts", templates.TypeScriptTemplate, *container)
+}
@@ -2,6 +2,8 @@
 * ADCSActuatorCommands
 * Commands for the attitude control actuators
 */
+import { ADCSControlMode, isADCSControlMode } from './ADCSControlMode';
+
 export interface ADCSActuatorCommands {
   /** Commanded reaction wheel speeds (rpm) */
   reactionWheelSpeeds: number[];
@@ -9,8 +11,26 @@ export interface ADCSActuatorCommands {
   magnetorquerCommands: number[];
   /** Timestamp of the actuator commands (ms) */
   commandTimestamp: number;
-  /** Current ADCS control mode (enum) */
-  controlMode: number;
+  /** Current ADCS control mode */
+  controlMode: ADCSControlMode;
+}
+
+/** Minimum serialized size of ADCSActuatorCommands in bytes */
+export const ADCSActuatorCommandsMinSize = 19;
+
+/** Maximum serialized size of ADCSActuatorCommands in bytes */
+export const ADCSActuatorCommandsMaxSize = 19;
+
+/** Fingerprint of the wire layout of ADCSActuatorCommands; peers built from a different layout have a different value */
+export const ADCSActuatorCommandsFingerprint = 0xD802194D;
+
+/**
+* Checks a peer's ADCSActuatorCommands layout fingerprint against this build
+* @param fingerprint The fingerprint reported by the peer
+* @returns True if both sides use the same wire layout
+*/
+export function checkADCSActuatorCommandsFingerprint(fingerprint: number): boolean {
+  return fingerprint === ADCSActuatorCommandsFingerprint;
 }
 
 /**
@@ -22,7 +42,7 @@ export function createADCSActuatorCommands(): ADCSActuatorCommands {
     reactionWheelSpeeds: Array(4).fill(0),
     magnetorquerCommands: Array(3).fill(0),
     commandTimestamp: 0,
-    controlMode: 0
+    controlMode: ADCSControlMode.Off
   };
 }
 
@@ -32,9 +52,29 @@ export function createADCSActuatorCommands(): ADCSActuatorCommands {
 * @returns An ArrayBuffer containing the serialized data
 */
 export function serializeADCSActuatorCommands(data: ADCSActuatorCommands): ArrayBuffer {
-  const buffer = new ArrayBuffer(19);
-  const view = new DataView(buffer);
-  let offset = 0;
+  const buffer = new ArrayBuffer(ADCSActuatorCommandsMaxSize);
+  const length = writeADCSActuatorCommands(new DataView(buffer), 0, data);
+  return buffer.slice(0, length);
+}
+
+/**
+* Deserializes an ArrayBuffer to a ADCSActuatorCommands object
+* @param buffer The ArrayBuffer containing serialized data
+* @returns A ADCSActuatorCommands object with the deserialized data
+*/
+export function deserializeADCSActuatorCommands(buffer: ArrayBuffer): ADCSActuatorCommands {
+  const [result] = readADCSActuatorCommands(new DataView(buffer), 0);
+  return result;
+}
+
+/**
+* Writes a ADCSActuatorCommands object into a DataView
+* @param view The DataView to write into
+* @param offset The byte offset to start writing at
+* @param data The ADCSActuatorCommands object to write
+* @returns The byte offset just past the written data
+*/
+export function writeADCSActuatorCommands(view: DataView, offset: number, data: ADCSActuatorCommands): number {
   // Serialize reactionWheelSpeeds array
   for (let i = 0; i < 4; i++) {
     view.setInt16(offset, data.reactionWheelSpeeds[i], true);
@@ -49,30 +89,32 @@ export function serializeADCSActuatorCommands(data: ADCSActuatorCommands): Array
   view.setUint32(offset, data.commandTimestamp, true);
   offset += 4;
   // Serialize controlMode scalar
+  if (!isADCSControlMode(data.controlMode)) {
+    throw new RangeError('Invalid ADCSControlMode value for controlMode: ' + data.controlMode);
+  }
   view.setUint8(offset, data.controlMode);
   offset += 1;
 
-  return buffer;
+  return offset;
 }
 
 /**
-* Deserializes an ArrayBuffer to a ADCSActuatorCommands object
-* @param buffer The ArrayBuffer containing serialized data
-* @returns A ADCSActuatorCommands object with the deserialized data
+* Reads a ADCSActuatorCommands object from a DataView
+* @param view The DataView to read from
+* @param offset The byte offset to start reading at
+* @returns The ADCSActuatorCommands object and the byte offset just past it
 */
-export function deserializeADCSActuatorCommands(buffer: ArrayBuffer): ADCSActuatorCommands {
-  const view = new DataView(buffer);
-  let offset = 0;
+export function readADCSActuatorCommands(view: DataView, offset: number): [ADCSActuatorCommands, number] {
   const result = createADCSActuatorCommands();
   // Deserialize reactionWheelSpeeds array
-  const reactionWheelSpeedsArray = [];
+  const reactionWheelSpeedsArray: number[] = [];
   for (let i = 0; i < 4; i++) {
     reactionWheelSpeedsArray.push(view.getInt16(offset, true));
     offset += 2;
   }
   result.reactionWheelSpeeds = reactionWheelSpeedsArray;
   // Deserialize magnetorquerCommands array
-  const magnetorquerCommandsArray = [];
+  const magnetorquerCommandsArray: number[] = [];
   for (let i = 0; i < 3; i++) {
     magnetorquerCommandsArray.push(view.getInt16(offset, true));
     offset += 2;
@@ -82,8 +124,12 @@ export function deserializeADCSActuatorCommands(buffer: ArrayBuffer): ADCSActuat
   result.commandTimestamp = view.getUint32(offset, true);
   offset += 4;
   // Deserialize controlMode scalar
-  result.controlMode = view.getUint8(offset);
+  const controlModeValue = view.getUint8(offset);
+  if (!isADCSControlMode(controlModeValue)) {
+    throw new RangeError('Invalid ADCSControlMode value for controlMode: ' + controlModeValue);
+  }
+  result.controlMode = controlModeValue;
   offset += 1;
 
-  return result;
+  return [result, offset];
 }
@@ -2,29 +2,51 @@
 * ADCSAttitudeState
 * Current attitude state of the spacecraft
 */
+import { AttitudeDeterminationMode, isAttitudeDeterminationMode } from './AttitudeDeterminationMode';
+import { Quaternion, createQuaternion, readQuaternion, writeQuaternion } from './Quaternion';
+import { Vector3, createVector3, readVector3, writeVector3 } from './Vector3';
+
 export interface ADCSAttitudeState {
-  /** Quaternion representing the spacecraft attitude (quaternion) */
-  quaternion: number[];
+  /** Quaternion representing the spacecraft attitude */
+  quaternion: Quaternion;
   /** Angular velocity vector of the spacecraft (rad/s) */
-  angularVelocity: number[];
+  angularVelocity: Vector3;
   /** Timestamp of the attitude measurement (ms) */
   timestamp: number;
-  /** Current mode of attitude determination (enum) */
-  attitudeDeterminationMode: number;
+  /** Current mode of attitude determination */
+  attitudeDeterminationMode: AttitudeDeterminationMode;
   /** Flag indicating if the attitude solution is valid */
   attitudeValid: boolean;
 }
 
+/** Minimum serialized size of ADCSAttitudeState in bytes */
+export const ADCSAttitudeStateMinSize = 34;
+
+/** Maximum serialized size of ADCSAttitudeState in bytes */
+export const ADCSAttitudeStateMaxSize = 34;
+
+/** Fingerprint of the wire layout of ADCSAttitudeState; peers built from a different layout have a different value */
+export const ADCSAttitudeStateFingerprint = 0x93D23BF8;
+
+/**
+* Checks a peer's ADCSAttitudeState layout fingerprint against this build
+* @param fingerprint The fingerprint reported by the peer
+* @returns True if both sides use the same wire layout
+*/
+export function checkADCSAttitudeStateFingerprint(fingerprint: number): boolean {
+  return fingerprint === ADCSAttitudeStateFingerprint;
+}
+
 /**
 * Creates a default ADCSAttitudeState object
 * @returns A new ADCSAttitudeState with default values
 */
 export function createADCSAttitudeState(): ADCSAttitudeState {
   return {
-    quaternion: Array(4).fill(0),
-    angularVelocity: Array(3).fill(0),
+    quaternion: createQuaternion(),
+    angularVelocity: createVector3(),
     timestamp: 0,
-    attitudeDeterminationMode: 0,
+    attitudeDeterminationMode: AttitudeDeterminationMode.None,
     attitudeValid: false
   };
 }
@@ -35,64 +57,74 @@ export function createADCSAttitudeState(): ADCSAttitudeState {
 * @returns An ArrayBuffer containing the serialized data
 */
 export function serializeADCSAttitudeState(data: ADCSAttitudeState): ArrayBuffer {
-  const buffer = new ArrayBuffer(34);
-  const view = new DataView(buffer);
-  let offset = 0;
-  // Serialize quaternion array
-  for (let i = 0; i < 4; i++) {
-    view.setFloat32(offset, data.quaternion[i], true);
-    offset += 4;
-  }
-  // Serialize angularVelocity array
-  for (let i = 0; i < 3; i++) {
-    view.setFloat32(offset, data.angularVelocity[i], true);
-    offset += 4;
-  }
+  const buffer = new ArrayBuffer(ADCSAttitudeStateMaxSize);
+  const length = writeADCSAttitudeState(new DataView(buffer), 0, data);
+  return buffer.slice(0, length);
+}
+
+/**
+* Deserializes an ArrayBuffer to a ADCSAttitudeState object
+* @param buffer The ArrayBuffer containing serialized data
+* @returns A ADCSAttitudeState object with the deserialized data
+*/
+export function deserializeADCSAttitudeState(buffer: ArrayBuffer): ADCSAttitudeState {
+  const [result] = readADCSAttitudeState(new DataView(buffer), 0);
+  return result;
+}
+
+/**
+* Writes a ADCSAttitudeState object into a DataView
+* @param view The DataView to write into
+* @param offset The byte offset to start writing at
+* @param data The ADCSAttitudeState object to write
+* @returns The byte offset just past the written data
+*/
+export function writeADCSAttitudeState(view: DataView, offset: number, data: ADCSAttitudeState): number {
+  // Serialize quaternion scalar
+  offset = writeQuaternion(view, offset, data.quaternion);
+  // Serialize angularVelocity scalar
+  offset = writeVector3(view, offset, data.angularVelocity);
   // Serialize timestamp scalar
   view.setUint32(offset, data.timestamp, true);
   offset += 4;
   // Serialize attitudeDeterminationMode scalar
+  if (!isAttitudeDeterminationMode(data.attitudeDeterminationMode)) {
+    throw new RangeError('Invalid AttitudeDeterminationMode value for attitudeDeterminationMode: ' + data.attitudeDeterminationMode);
+  }
   view.setUint8(offset, data.attitudeDeterminationMode);
   offset += 1;
   // Serialize attitudeValid scalar
   view.setUint8(offset, data.attitudeValid ? 1 : 0);
   offset += 1;
 
-  return buffer;
+  return offset;
 }
 
 /**
-* Deserializes an ArrayBuffer to a ADCSAttitudeState object
-* @param buffer The ArrayBuffer containing serialized data
-* @returns A ADCSAttitudeState object with the deserialized data
+* Reads a ADCSAttitudeState object from a DataView
+* @param view The DataView to read from
+* @param offset The byte offset to start reading at
+* @returns The ADCSAttitudeState object and the byte offset just past it
 */
-export function deserializeADCSAttitudeState(buffer: ArrayBuffer): ADCSAttitudeState {
-  const view = new DataView(buffer);
-  let offset = 0;
+export function readADCSAttitudeState(view: DataView, offset: number): [ADCSAttitudeState, number] {
   const result = createADCSAttitudeState();
-  // Deserialize quaternion array
-  const quaternionArray = [];
-  for (let i = 0; i < 4; i++) {
-    quaternionArray.push(view.getFloat32(offset, true));
-    offset += 4;
-  }
-  result.quaternion = quaternionArray;
-  // Deserialize angularVelocity array
-  const angularVelocityArray = [];
-  for (let i = 0; i < 3; i++) {
-    angularVelocityArray.push(view.getFloat32(offset, true));
-    offset += 4;
-  }
-  result.angularVelocity = angularVelocityArray;
+  // Deserialize quaternion scalar
+  [result.quaternion, offset] = readQuaternion(view, offset);
+  // Deserialize angularVelocity scalar
+  [result.angularVelocity, offset] = readVector3(view, offset);
   // Deserialize timestamp scalar
   result.timestamp = view.getUint32(offset, true);
   offset += 4;
   // Deserialize attitudeDeterminationMode scalar
-  result.attitudeDeterminationMode = view.getUint8(offset);
+  const attitudeDeterminationModeValue = view.getUint8(offset);
+  if (!isAttitudeDeterminationMode(attitudeDeterminationModeValue)) {
+    throw new RangeError('Invalid AttitudeDeterminationMode value for attitudeDeterminationMode: ' + attitudeDeterminationModeValue);
+  }
+  result.attitudeDeterminationMode = attitudeDeterminationModeValue;
   offset += 1;
   // Deserialize attitudeValid scalar
   result.attitudeValid = view.getUint8(offset) !== 0;
   offset += 1;
 
-  return result;
+  return [result, offset];
 }
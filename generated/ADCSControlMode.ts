@@ -0,0 +1,47 @@
+/**
+* ADCSControlMode
+* Attitude control mode of the ADCS
+*/
+export enum ADCSControlMode {
+  /** Actuators disabled */
+  Off = 0,
+  /** B-dot detumbling with magnetorquers */
+  Detumble = 1,
+  /** Point solar arrays at the sun */
+  SunPointing = 2,
+  /** Point payload at nadir */
+  NadirPointing = 3,
+  /** Track a commanded ground target */
+  TargetTracking = 4,
+}
+
+/**
+* Maps each ADCSControlMode value to its name
+*/
+export const ADCSControlModeNames: Record<number, string> = {
+  '0': 'Off',
+  '1': 'Detumble',
+  '2': 'SunPointing',
+  '3': 'NadirPointing',
+  '4': 'TargetTracking',
+};
+
+/**
+* Maps each ADCSControlMode value to its description
+*/
+export const ADCSControlModeDescriptions: Record<number, string> = {
+  '0': "Actuators disabled",
+  '1': "B-dot detumbling with magnetorquers",
+  '2': "Point solar arrays at the sun",
+  '3': "Point payload at nadir",
+  '4': "Track a commanded ground target",
+};
+
+/**
+* Checks whether a raw wire value is a legal ADCSControlMode
+* @param value The raw value to check
+* @returns True if the value names a ADCSControlMode member
+*/
+export function isADCSControlMode(value: number): value is ADCSControlMode {
+  return Object.prototype.hasOwnProperty.call(ADCSControlModeNames, value);
+}
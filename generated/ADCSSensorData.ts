@@ -2,17 +2,37 @@
 * ADCSSensorData
 * Raw sensor data from ADCS sensors
 */
+import { Vector3, createVector3, readVector3, writeVector3 } from './Vector3';
+
 export interface ADCSSensorData {
   /** Raw magnetometer readings (nT) */
   magnetometerReadings: number[];
   /** Raw sun sensor readings (counts) */
   sunSensorReadings: number[];
   /** Gyroscope readings (rad/s) */
-  gyroscopeReadings: number[];
+  gyroscopeReadings: Vector3;
   /** Timestamp of the sensor readings (ms) */
   sensorTimestamp: number;
   /** Bitmask of currently enabled sensors */
-  sensorsEnabled: number;
+  sensorsEnabled: { magnetometer: boolean; sunSensors: boolean; gyroscope: boolean; starTracker: boolean };
+}
+
+/** Minimum serialized size of ADCSSensorData in bytes */
+export const ADCSSensorDataMinSize = 35;
+
+/** Maximum serialized size of ADCSSensorData in bytes */
+export const ADCSSensorDataMaxSize = 35;
+
+/** Fingerprint of the wire layout of ADCSSensorData; peers built from a different layout have a different value */
+export const ADCSSensorDataFingerprint = 0xCD3877E4;
+
+/**
+* Checks a peer's ADCSSensorData layout fingerprint against this build
+* @param fingerprint The fingerprint reported by the peer
+* @returns True if both sides use the same wire layout
+*/
+export function checkADCSSensorDataFingerprint(fingerprint: number): boolean {
+  return fingerprint === ADCSSensorDataFingerprint;
 }
 
 /**
@@ -23,9 +43,9 @@ export function createADCSSensorData(): ADCSSensorData {
   return {
     magnetometerReadings: Array(3).fill(0),
     sunSensorReadings: Array(6).fill(0),
-    gyroscopeReadings: Array(3).fill(0),
+    gyroscopeReadings: createVector3(),
     sensorTimestamp: 0,
-    sensorsEnabled: 0
+    sensorsEnabled: { magnetometer: false, sunSensors: false, gyroscope: false, starTracker: false }
   };
 }
 
@@ -35,9 +55,29 @@ export function createADCSSensorData(): ADCSSensorData {
 * @returns An ArrayBuffer containing the serialized data
 */
 export function serializeADCSSensorData(data: ADCSSensorData): ArrayBuffer {
-  const buffer = new ArrayBuffer(35);
-  const view = new DataView(buffer);
-  let offset = 0;
+  const buffer = new ArrayBuffer(ADCSSensorDataMaxSize);
+  const length = writeADCSSensorData(new DataView(buffer), 0, data);
+  return buffer.slice(0, length);
+}
+
+/**
+* Deserializes an ArrayBuffer to a ADCSSensorData object
+* @param buffer The ArrayBuffer containing serialized data
+* @returns A ADCSSensorData object with the deserialized data
+*/
+export function deserializeADCSSensorData(buffer: ArrayBuffer): ADCSSensorData {
+  const [result] = readADCSSensorData(new DataView(buffer), 0);
+  return result;
+}
+
+/**
+* Writes a ADCSSensorData object into a DataView
+* @param view The DataView to write into
+* @param offset The byte offset to start writing at
+* @param data The ADCSSensorData object to write
+* @returns The byte offset just past the written data
+*/
+export function writeADCSSensorData(view: DataView, offset: number, data: ADCSSensorData): number {
   // Serialize magnetometerReadings array
   for (let i = 0; i < 3; i++) {
     view.setInt16(offset, data.magnetometerReadings[i], true);
@@ -48,57 +88,57 @@ export function serializeADCSSensorData(data: ADCSSensorData): ArrayBuffer {
     view.setUint16(offset, data.sunSensorReadings[i], true);
     offset += 2;
   }
-  // Serialize gyroscopeReadings array
-  for (let i = 0; i < 3; i++) {
-    view.setFloat32(offset, data.gyroscopeReadings[i], true);
-    offset += 4;
-  }
+  // Serialize gyroscopeReadings scalar
+  offset = writeVector3(view, offset, data.gyroscopeReadings);
   // Serialize sensorTimestamp scalar
   view.setUint32(offset, data.sensorTimestamp, true);
   offset += 4;
   // Serialize sensorsEnabled scalar
-  view.setUint8(offset, data.sensorsEnabled);
+  let sensorsEnabledPacked = 0;
+  sensorsEnabledPacked |= (data.sensorsEnabled.magnetometer ? 1 : 0) << 0;
+  sensorsEnabledPacked |= (data.sensorsEnabled.sunSensors ? 1 : 0) << 1;
+  sensorsEnabledPacked |= (data.sensorsEnabled.gyroscope ? 1 : 0) << 2;
+  sensorsEnabledPacked |= (data.sensorsEnabled.starTracker ? 1 : 0) << 3;
+  view.setUint8(offset, sensorsEnabledPacked >>> 0);
   offset += 1;
 
-  return buffer;
+  return offset;
 }
 
 /**
-* Deserializes an ArrayBuffer to a ADCSSensorData object
-* @param buffer The ArrayBuffer containing serialized data
-* @returns A ADCSSensorData object with the deserialized data
+* Reads a ADCSSensorData object from a DataView
+* @param view The DataView to read from
+* @param offset The byte offset to start reading at
+* @returns The ADCSSensorData object and the byte offset just past it
 */
-export function deserializeADCSSensorData(buffer: ArrayBuffer): ADCSSensorData {
-  const view = new DataView(buffer);
-  let offset = 0;
+export function readADCSSensorData(view: DataView, offset: number): [ADCSSensorData, number] {
   const result = createADCSSensorData();
   // Deserialize magnetometerReadings array
-  const magnetometerReadingsArray = [];
+  const magnetometerReadingsArray: number[] = [];
   for (let i = 0; i < 3; i++) {
     magnetometerReadingsArray.push(view.getInt16(offset, true));
     offset += 2;
   }
   result.magnetometerReadings = magnetometerReadingsArray;
   // Deserialize sunSensorReadings array
-  const sunSensorReadingsArray = [];
+  const sunSensorReadingsArray: number[] = [];
   for (let i = 0; i < 6; i++) {
     sunSensorReadingsArray.push(view.getUint16(offset, true));
     offset += 2;
   }
   result.sunSensorReadings = sunSensorReadingsArray;
-  // Deserialize gyroscopeReadings array
-  const gyroscopeReadingsArray = [];
-  for (let i = 0; i < 3; i++) {
-    gyroscopeReadingsArray.push(view.getFloat32(offset, true));
-    offset += 4;
-  }
-  result.gyroscopeReadings = gyroscopeReadingsArray;
+  // Deserialize gyroscopeReadings scalar
+  [result.gyroscopeReadings, offset] = readVector3(view, offset);
   // Deserialize sensorTimestamp scalar
   result.sensorTimestamp = view.getUint32(offset, true);
   offset += 4;
   // Deserialize sensorsEnabled scalar
-  result.sensorsEnabled = view.getUint8(offset);
+  const sensorsEnabledPacked = view.getUint8(offset);
+  result.sensorsEnabled.magnetometer = ((sensorsEnabledPacked >>> 0) & 1) !== 0;
+  result.sensorsEnabled.sunSensors = ((sensorsEnabledPacked >>> 1) & 1) !== 0;
+  result.sensorsEnabled.gyroscope = ((sensorsEnabledPacked >>> 2) & 1) !== 0;
+  result.sensorsEnabled.starTracker = ((sensorsEnabledPacked >>> 3) & 1) !== 0;
   offset += 1;
 
-  return result;
+  return [result, offset];
 }
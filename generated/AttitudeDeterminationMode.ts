@@ -0,0 +1,43 @@
+/**
+* AttitudeDeterminationMode
+* Source of the current attitude solution
+*/
+export enum AttitudeDeterminationMode {
+  /** No attitude solution available */
+  None = 0,
+  /** Coarse solution from sun sensors and magnetometer */
+  SunMagnetometer = 1,
+  /** Propagated from gyroscope readings */
+  Gyroscope = 2,
+  /** Fine solution from the star tracker */
+  StarTracker = 3,
+}
+
+/**
+* Maps each AttitudeDeterminationMode value to its name
+*/
+export const AttitudeDeterminationModeNames: Record<number, string> = {
+  '0': 'None',
+  '1': 'SunMagnetometer',
+  '2': 'Gyroscope',
+  '3': 'StarTracker',
+};
+
+/**
+* Maps each AttitudeDeterminationMode value to its description
+*/
+export const AttitudeDeterminationModeDescriptions: Record<number, string> = {
+  '0': "No attitude solution available",
+  '1': "Coarse solution from sun sensors and magnetometer",
+  '2': "Propagated from gyroscope readings",
+  '3': "Fine solution from the star tracker",
+};
+
+/**
+* Checks whether a raw wire value is a legal AttitudeDeterminationMode
+* @param value The raw value to check
+* @returns True if the value names a AttitudeDeterminationMode member
+*/
+export function isAttitudeDeterminationMode(value: number): value is AttitudeDeterminationMode {
+  return Object.prototype.hasOwnProperty.call(AttitudeDeterminationModeNames, value);
+}
@@ -0,0 +1,114 @@
+/**
+* Quaternion
+* Attitude quaternion with the scalar part last
+*/
+export interface Quaternion {
+  /** First vector component */
+  x: number;
+  /** Second vector component */
+  y: number;
+  /** Third vector component */
+  z: number;
+  /** Scalar component */
+  w: number;
+}
+
+/** Minimum serialized size of Quaternion in bytes */
+export const QuaternionMinSize = 16;
+
+/** Maximum serialized size of Quaternion in bytes */
+export const QuaternionMaxSize = 16;
+
+/** Fingerprint of the wire layout of Quaternion; peers built from a different layout have a different value */
+export const QuaternionFingerprint = 0xE3D5898D;
+
+/**
+* Checks a peer's Quaternion layout fingerprint against this build
+* @param fingerprint The fingerprint reported by the peer
+* @returns True if both sides use the same wire layout
+*/
+export function checkQuaternionFingerprint(fingerprint: number): boolean {
+  return fingerprint === QuaternionFingerprint;
+}
+
+/**
+* Creates a default Quaternion object
+* @returns A new Quaternion with default values
+*/
+export function createQuaternion(): Quaternion {
+  return {
+    x: 0,
+    y: 0,
+    z: 0,
+    w: 0
+  };
+}
+
+/**
+* Serializes a Quaternion object to an ArrayBuffer
+* @param data The Quaternion object to serialize
+* @returns An ArrayBuffer containing the serialized data
+*/
+export function serializeQuaternion(data: Quaternion): ArrayBuffer {
+  const buffer = new ArrayBuffer(QuaternionMaxSize);
+  const length = writeQuaternion(new DataView(buffer), 0, data);
+  return buffer.slice(0, length);
+}
+
+/**
+* Deserializes an ArrayBuffer to a Quaternion object
+* @param buffer The ArrayBuffer containing serialized data
+* @returns A Quaternion object with the deserialized data
+*/
+export function deserializeQuaternion(buffer: ArrayBuffer): Quaternion {
+  const [result] = readQuaternion(new DataView(buffer), 0);
+  return result;
+}
+
+/**
+* Writes a Quaternion object into a DataView
+* @param view The DataView to write into
+* @param offset The byte offset to start writing at
+* @param data The Quaternion object to write
+* @returns The byte offset just past the written data
+*/
+export function writeQuaternion(view: DataView, offset: number, data: Quaternion): number {
+  // Serialize x scalar
+  view.setFloat32(offset, data.x, true);
+  offset += 4;
+  // Serialize y scalar
+  view.setFloat32(offset, data.y, true);
+  offset += 4;
+  // Serialize z scalar
+  view.setFloat32(offset, data.z, true);
+  offset += 4;
+  // Serialize w scalar
+  view.setFloat32(offset, data.w, true);
+  offset += 4;
+
+  return offset;
+}
+
+/**
+* Reads a Quaternion object from a DataView
+* @param view The DataView to read from
+* @param offset The byte offset to start reading at
+* @returns The Quaternion object and the byte offset just past it
+*/
+export function readQuaternion(view: DataView, offset: number): [Quaternion, number] {
+  const result = createQuaternion();
+  // Deserialize x scalar
+  result.x = view.getFloat32(offset, true);
+  offset += 4;
+  // Deserialize y scalar
+  result.y = view.getFloat32(offset, true);
+  offset += 4;
+  // Deserialize z scalar
+  result.z = view.getFloat32(offset, true);
+  offset += 4;
+  // Deserialize w scalar
+  result.w = view.getFloat32(offset, true);
+  offset += 4;
+
+  return [result, offset];
+}
@@ -0,0 +1,105 @@
+/**
+* Vector3
+* Three-axis vector in the spacecraft body frame
+*/
+export interface Vector3 {
+  /** X axis component */
+  x: number;
+  /** Y axis component */
+  y: number;
+  /** Z axis component */
+  z: number;
+}
+
+/** Minimum serialized size of Vector3 in bytes */
+export const Vector3MinSize = 12;
+
+/** Maximum serialized size of Vector3 in bytes */
+export const Vector3MaxSize = 12;
+
+/** Fingerprint of the wire layout of Vector3; peers built from a different layout have a different value */
+export const Vector3Fingerprint = 0x64A0E28A;
+
+/**
+* Checks a peer's Vector3 layout fingerprint against this build
+* @param fingerprint The fingerprint reported by the peer
+* @returns True if both sides use the same wire layout
+*/
+export function checkVector3Fingerprint(fingerprint: number): boolean {
+  return fingerprint === Vector3Fingerprint;
+}
+
+/**
+* Creates a default Vector3 object
+* @returns A new Vector3 with default values
+*/
+export function createVector3(): Vector3 {
+  return {
+    x: 0,
+    y: 0,
+    z: 0
+  };
+}
+
+/**
+* Serializes a Vector3 object to an ArrayBuffer
+* @param data The Vector3 object to serialize
+* @returns An ArrayBuffer containing the serialized data
+*/
+export function serializeVector3(data: Vector3): ArrayBuffer {
+  const buffer = new ArrayBuffer(Vector3MaxSize);
+  const length = writeVector3(new DataView(buffer), 0, data);
+  return buffer.slice(0, length);
+}
+
+/**
+* Deserializes an ArrayBuffer to a Vector3 object
+* @param buffer The ArrayBuffer containing serialized data
+* @returns A Vector3 object with the deserialized data
+*/
+export function deserializeVector3(buffer: ArrayBuffer): Vector3 {
+  const [result] = readVector3(new DataView(buffer), 0);
+  return result;
+}
+
+/**
+* Writes a Vector3 object into a DataView
+* @param view The DataView to write into
+* @param offset The byte offset to start writing at
+* @param data The Vector3 object to write
+* @returns The byte offset just past the written data
+*/
+export function writeVector3(view: DataView, offset: number, data: Vector3): number {
+  // Serialize x scalar
+  view.setFloat32(offset, data.x, true);
+  offset += 4;
+  // Serialize y scalar
+  view.setFloat32(offset, data.y, true);
+  offset += 4;
+  // Serialize z scalar
+  view.setFloat32(offset, data.z, true);
+  offset += 4;
+
+  return offset;
+}
+
+/**
+* Reads a Vector3 object from a DataView
+* @param view The DataView to read from
+* @param offset The byte offset to start reading at
+* @returns The Vector3 object and the byte offset just past it
+*/
+export function readVector3(view: DataView, offset: number): [Vector3, number] {
+  const result = createVector3();
+  // Deserialize x scalar
+  result.x = view.getFloat32(offset, true);
+  offset += 4;
+  // Deserialize y scalar
+  result.y = view.getFloat32(offset, true);
+  offset += 4;
+  // Deserialize z scalar
+  result.z = view.getFloat32(offset, true);
+  offset += 4;
+
+  return [result, offset];
+}
@@ -0,0 +1,512 @@
+"""
+adcs
+Generated by uscdl. Do not edit.
+"""
+
+from __future__ import annotations
+
+import struct
+from dataclasses import dataclass, field
+from enum import IntEnum
+from typing import Any, ClassVar, Dict, List, Tuple, Type
+
+
+def _unpack(fmt: str, data: bytes, offset: int, name: str) -> Tuple[Any, int]:
+    """Reads one struct value at offset and returns it with the next offset"""
+    size = struct.calcsize(fmt)
+    if offset + size > len(data):
+        raise ValueError(f"{name}: need {size} bytes at offset {offset}, have {len(data) - offset}")
+    return struct.unpack_from(fmt, data, offset)[0], offset + size
+
+
+def _take(data: bytes, offset: int, size: int, name: str) -> Tuple[bytes, int]:
+    """Reads size raw bytes at offset and returns them with the next offset"""
+    if offset + size > len(data):
+        raise ValueError(f"{name}: need {size} bytes at offset {offset}, have {len(data) - offset}")
+    return bytes(data[offset:offset + size]), offset + size
+
+
+class AttitudeDeterminationMode(IntEnum):
+    """Source of the current attitude solution"""
+
+    None_ = 0
+    """No attitude solution available"""
+
+    SunMagnetometer = 1
+    """Coarse solution from sun sensors and magnetometer"""
+
+    Gyroscope = 2
+    """Propagated from gyroscope readings"""
+
+    StarTracker = 3
+    """Fine solution from the star tracker"""
+
+
+class ADCSControlMode(IntEnum):
+    """Attitude control mode of the ADCS"""
+
+    Off = 0
+    """Actuators disabled"""
+
+    Detumble = 1
+    """B-dot detumbling with magnetorquers"""
+
+    SunPointing = 2
+    """Point solar arrays at the sun"""
+
+    NadirPointing = 3
+    """Point payload at nadir"""
+
+    TargetTracking = 4
+    """Track a commanded ground target"""
+
+
+@dataclass
+class Vector3:
+    """Three-axis vector in the spacecraft body frame"""
+
+    MIN_SIZE: ClassVar[int] = 12
+    """Minimum packed size of Vector3 in bytes"""
+    MAX_SIZE: ClassVar[int] = 12
+    """Maximum packed size of Vector3 in bytes"""
+    FINGERPRINT: ClassVar[int] = 0x64A0E28A
+    """Fingerprint of the wire layout of Vector3"""
+
+    x: float = field(default=0.0, metadata={"name": "x", "description": "X axis component"})
+    """X axis component"""
+
+    y: float = field(default=0.0, metadata={"name": "y", "description": "Y axis component"})
+    """Y axis component"""
+
+    z: float = field(default=0.0, metadata={"name": "z", "description": "Z axis component"})
+    """Z axis component"""
+
+    def pack(self) -> bytes:
+        """Packs this Vector3 into bytes"""
+        buf = bytearray()
+
+        # x
+        buf += struct.pack("<f", self.x)
+
+        # y
+        buf += struct.pack("<f", self.y)
+
+        # z
+        buf += struct.pack("<f", self.z)
+
+        return bytes(buf)
+
+    @classmethod
+    def check_fingerprint(cls, fingerprint: int) -> bool:
+        """Returns whether a peer's layout fingerprint matches this build"""
+        return fingerprint == cls.FINGERPRINT
+
+    @classmethod
+    def unpack(cls, data: bytes) -> Vector3:
+        """Unpacks a Vector3 from bytes"""
+        obj, _ = cls.unpack_from(data)
+        return obj
+
+    @classmethod
+    def unpack_from(cls, data: bytes, offset: int = 0) -> Tuple[Vector3, int]:
+        """Unpacks a Vector3 at offset and returns it with the next offset"""
+        obj = cls()
+
+        # x
+        obj.x, offset = _unpack("<f", data, offset, "x")
+
+        # y
+        obj.y, offset = _unpack("<f", data, offset, "y")
+
+        # z
+        obj.z, offset = _unpack("<f", data, offset, "z")
+
+        return obj, offset
+
+
+@dataclass
+class Quaternion:
+    """Attitude quaternion with the scalar part last"""
+
+    MIN_SIZE: ClassVar[int] = 16
+    """Minimum packed size of Quaternion in bytes"""
+    MAX_SIZE: ClassVar[int] = 16
+    """Maximum packed size of Quaternion in bytes"""
+    FINGERPRINT: ClassVar[int] = 0xE3D5898D
+    """Fingerprint of the wire layout of Quaternion"""
+
+    x: float = field(default=0.0, metadata={"name": "x", "description": "First vector component"})
+    """First vector component"""
+
+    y: float = field(default=0.0, metadata={"name": "y", "description": "Second vector component"})
+    """Second vector component"""
+
+    z: float = field(default=0.0, metadata={"name": "z", "description": "Third vector component"})
+    """Third vector component"""
+
+    w: float = field(default=0.0, metadata={"name": "w", "description": "Scalar component"})
+    """Scalar component"""
+
+    def pack(self) -> bytes:
+        """Packs this Quaternion into bytes"""
+        buf = bytearray()
+
+        # x
+        buf += struct.pack("<f", self.x)
+
+        # y
+        buf += struct.pack("<f", self.y)
+
+        # z
+        buf += struct.pack("<f", self.z)
+
+        # w
+        buf += struct.pack("<f", self.w)
+
+        return bytes(buf)
+
+    @classmethod
+    def check_fingerprint(cls, fingerprint: int) -> bool:
+        """Returns whether a peer's layout fingerprint matches this build"""
+        return fingerprint == cls.FINGERPRINT
+
+    @classmethod
+    def unpack(cls, data: bytes) -> Quaternion:
+        """Unpacks a Quaternion from bytes"""
+        obj, _ = cls.unpack_from(data)
+        return obj
+
+    @classmethod
+    def unpack_from(cls, data: bytes, offset: int = 0) -> Tuple[Quaternion, int]:
+        """Unpacks a Quaternion at offset and returns it with the next offset"""
+        obj = cls()
+
+        # x
+        obj.x, offset = _unpack("<f", data, offset, "x")
+
+        # y
+        obj.y, offset = _unpack("<f", data, offset, "y")
+
+        # z
+        obj.z, offset = _unpack("<f", data, offset, "z")
+
+        # w
+        obj.w, offset = _unpack("<f", data, offset, "w")
+
+        return obj, offset
+
+
+@dataclass
+class ADCSAttitudeState:
+    """Current attitude state of the spacecraft"""
+
+    MIN_SIZE: ClassVar[int] = 34
+    """Minimum packed size of ADCSAttitudeState in bytes"""
+    MAX_SIZE: ClassVar[int] = 34
+    """Maximum packed size of ADCSAttitudeState in bytes"""
+    FINGERPRINT: ClassVar[int] = 0x93D23BF8
+    """Fingerprint of the wire layout of ADCSAttitudeState"""
+
+    quaternion: Quaternion = field(default_factory=lambda: Quaternion(), metadata={"name": "quaternion", "description": "Quaternion representing the spacecraft attitude"})
+    """Quaternion representing the spacecraft attitude"""
+
+    angular_velocity: Vector3 = field(default_factory=lambda: Vector3(), metadata={"name": "angularVelocity", "description": "Angular velocity vector of the spacecraft", "units": "rad/s"})
+    """Angular velocity vector of the spacecraft (rad/s)"""
+
+    timestamp: int = field(default=0, metadata={"name": "timestamp", "description": "Timestamp of the attitude measurement", "units": "ms"})
+    """Timestamp of the attitude measurement (ms)"""
+
+    attitude_determination_mode: AttitudeDeterminationMode = field(default=AttitudeDeterminationMode.None_, metadata={"name": "attitudeDeterminationMode", "description": "Current mode of attitude determination"})
+    """Current mode of attitude determination"""
+
+    attitude_valid: bool = field(default=False, metadata={"name": "attitudeValid", "description": "Flag indicating if the attitude solution is valid"})
+    """Flag indicating if the attitude solution is valid"""
+
+    def pack(self) -> bytes:
+        """Packs this ADCSAttitudeState into bytes"""
+        buf = bytearray()
+
+        # quaternion
+        buf += self.quaternion.pack()
+
+        # angularVelocity
+        buf += self.angular_velocity.pack()
+
+        # timestamp
+        buf += struct.pack("<I", self.timestamp)
+
+        # attitudeDeterminationMode
+        buf += struct.pack("<B", AttitudeDeterminationMode(self.attitude_determination_mode))
+
+        # attitudeValid
+        buf += struct.pack("<?", self.attitude_valid)
+
+        return bytes(buf)
+
+    @classmethod
+    def check_fingerprint(cls, fingerprint: int) -> bool:
+        """Returns whether a peer's layout fingerprint matches this build"""
+        return fingerprint == cls.FINGERPRINT
+
+    @classmethod
+    def unpack(cls, data: bytes) -> ADCSAttitudeState:
+        """Unpacks a ADCSAttitudeState from bytes"""
+        obj, _ = cls.unpack_from(data)
+        return obj
+
+    @classmethod
+    def unpack_from(cls, data: bytes, offset: int = 0) -> Tuple[ADCSAttitudeState, int]:
+        """Unpacks a ADCSAttitudeState at offset and returns it with the next offset"""
+        obj = cls()
+
+        # quaternion
+        obj.quaternion, offset = Quaternion.unpack_from(data, offset)
+
+        # angularVelocity
+        obj.angular_velocity, offset = Vector3.unpack_from(data, offset)
+
+        # timestamp
+        obj.timestamp, offset = _unpack("<I", data, offset, "timestamp")
+
+        # attitudeDeterminationMode
+        raw, offset = _unpack("<B", data, offset, "attitudeDeterminationMode")
+        obj.attitude_determination_mode = AttitudeDeterminationMode(raw)
+
+        # attitudeValid
+        obj.attitude_valid, offset = _unpack("<?", data, offset, "attitudeValid")
+
+        return obj, offset
+
+
+@dataclass
+class ADCSSensorData:
+    """Raw sensor data from ADCS sensors"""
+
+    MIN_SIZE: ClassVar[int] = 35
+    """Minimum packed size of ADCSSensorData in bytes"""
+    MAX_SIZE: ClassVar[int] = 35
+    """Maximum packed size of ADCSSensorData in bytes"""
+    FINGERPRINT: ClassVar[int] = 0xCD3877E4
+    """Fingerprint of the wire layout of ADCSSensorData"""
+
+    magnetometer_readings: List[int] = field(default_factory=lambda: [0] * 3, metadata={"name": "magnetometerReadings", "description": "Raw magnetometer readings", "units": "nT"})
+    """Raw magnetometer readings (nT)"""
+
+    sun_sensor_readings: List[int] = field(default_factory=lambda: [0] * 6, metadata={"name": "sunSensorReadings", "description": "Raw sun sensor readings", "units": "counts"})
+    """Raw sun sensor readings (counts)"""
+
+    gyroscope_readings: Vector3 = field(default_factory=lambda: Vector3(), metadata={"name": "gyroscopeReadings", "description": "Gyroscope readings", "units": "rad/s"})
+    """Gyroscope readings (rad/s)"""
+
+    sensor_timestamp: int = field(default=0, metadata={"name": "sensorTimestamp", "description": "Timestamp of the sensor readings", "units": "ms"})
+    """Timestamp of the sensor readings (ms)"""
+
+    sensors_enabled: int = field(default=0, metadata={"name": "sensorsEnabled", "description": "Bitmask of currently enabled sensors"})
+    """Bitmask of currently enabled sensors"""
+
+    @property
+    def sensors_enabled_magnetometer(self) -> bool:
+        """Magnetometer enabled"""
+        return bool((self.sensors_enabled >> 0) & 0x1)
+
+    @sensors_enabled_magnetometer.setter
+    def sensors_enabled_magnetometer(self, value: bool) -> None:
+        self.sensors_enabled = (self.sensors_enabled & ~(0x1 << 0)) | ((int(value) & 0x1) << 0)
+
+    @property
+    def sensors_enabled_sun_sensors(self) -> bool:
+        """Sun sensors enabled"""
+        return bool((self.sensors_enabled >> 1) & 0x1)
+
+    @sensors_enabled_sun_sensors.setter
+    def sensors_enabled_sun_sensors(self, value: bool) -> None:
+        self.sensors_enabled = (self.sensors_enabled & ~(0x1 << 1)) | ((int(value) & 0x1) << 1)
+
+    @property
+    def sensors_enabled_gyroscope(self) -> bool:
+        """Gyroscope enabled"""
+        return bool((self.sensors_enabled >> 2) & 0x1)
+
+    @sensors_enabled_gyroscope.setter
+    def sensors_enabled_gyroscope(self, value: bool) -> None:
+        self.sensors_enabled = (self.sensors_enabled & ~(0x1 << 2)) | ((int(value) & 0x1) << 2)
+
+    @property
+    def sensors_enabled_star_tracker(self) -> bool:
+        """Star tracker enabled"""
+        return bool((self.sensors_enabled >> 3) & 0x1)
+
+    @sensors_enabled_star_tracker.setter
+    def sensors_enabled_star_tracker(self, value: bool) -> None:
+        self.sensors_enabled = (self.sensors_enabled & ~(0x1 << 3)) | ((int(value) & 0x1) << 3)
+
+    def pack(self) -> bytes:
+        """Packs this ADCSSensorData into bytes"""
+        buf = bytearray()
+
+        # magnetometerReadings
+        if len(self.magnetometer_readings) != 3:
+            raise ValueError(f"magnetometerReadings: expected 3 elements, got {len(self.magnetometer_readings)}")
+        for value in self.magnetometer_readings:
+            buf += struct.pack("<h", value)
+
+        # sunSensorReadings
+        if len(self.sun_sensor_readings) != 6:
+            raise ValueError(f"sunSensorReadings: expected 6 elements, got {len(self.sun_sensor_readings)}")
+        for value in self.sun_sensor_readings:
+            buf += struct.pack("<H", value)
+
+        # gyroscopeReadings
+        buf += self.gyroscope_readings.pack()
+
+        # sensorTimestamp
+        buf += struct.pack("<I", self.sensor_timestamp)
+
+        # sensorsEnabled
+        buf += struct.pack("<B", self.sensors_enabled)
+
+        return bytes(buf)
+
+    @classmethod
+    def check_fingerprint(cls, fingerprint: int) -> bool:
+        """Returns whether a peer's layout fingerprint matches this build"""
+        return fingerprint == cls.FINGERPRINT
+
+    @classmethod
+    def unpack(cls, data: bytes) -> ADCSSensorData:
+        """Unpacks a ADCSSensorData from bytes"""
+        obj, _ = cls.unpack_from(data)
+        return obj
+
+    @classmethod
+    def unpack_from(cls, data: bytes, offset: int = 0) -> Tuple[ADCSSensorData, int]:
+        """Unpacks a ADCSSensorData at offset and returns it with the next offset"""
+        obj = cls()
+
+        # magnetometerReadings
+        count = 3
+        obj.magnetometer_readings = []
+        for _ in range(count):
+            value, offset = _unpack("<h", data, offset, "magnetometerReadings")
+            obj.magnetometer_readings.append(value)
+
+        # sunSensorReadings
+        count = 6
+        obj.sun_sensor_readings = []
+        for _ in range(count):
+            value, offset = _unpack("<H", data, offset, "sunSensorReadings")
+            obj.sun_sensor_readings.append(value)
+
+        # gyroscopeReadings
+        obj.gyroscope_readings, offset = Vector3.unpack_from(data, offset)
+
+        # sensorTimestamp
+        obj.sensor_timestamp, offset = _unpack("<I", data, offset, "sensorTimestamp")
+
+        # sensorsEnabled
+        obj.sensors_enabled, offset = _unpack("<B", data, offset, "sensorsEnabled")
+
+        return obj, offset
+
+
+@dataclass
+class ADCSActuatorCommands:
+    """Commands for the attitude control actuators"""
+
+    MIN_SIZE: ClassVar[int] = 19
+    """Minimum packed size of ADCSActuatorCommands in bytes"""
+    MAX_SIZE: ClassVar[int] = 19
+    """Maximum packed size of ADCSActuatorCommands in bytes"""
+    FINGERPRINT: ClassVar[int] = 0xD802194D
+    """Fingerprint of the wire layout of ADCSActuatorCommands"""
+
+    reaction_wheel_speeds: List[int] = field(default_factory=lambda: [0] * 4, metadata={"name": "reactionWheelSpeeds", "description": "Commanded reaction wheel speeds", "units": "rpm"})
+    """Commanded reaction wheel speeds (rpm)"""
+
+    magnetorquer_commands: List[int] = field(default_factory=lambda: [0] * 3, metadata={"name": "magnetorquerCommands", "description": "Commanded magnetorquer dipole moments", "units": "mA·m²"})
+    """Commanded magnetorquer dipole moments (mA·m²)"""
+
+    command_timestamp: int = field(default=0, metadata={"name": "commandTimestamp", "description": "Timestamp of the actuator commands", "units": "ms"})
+    """Timestamp of the actuator commands (ms)"""
+
+    control_mode: ADCSControlMode = field(default=ADCSControlMode.Off, metadata={"name": "controlMode", "description": "Current ADCS control mode"})
+    """Current ADCS control mode"""
+
+    def pack(self) -> bytes:
+        """Packs this ADCSActuatorCommands into bytes"""
+        buf = bytearray()
+
+        # reactionWheelSpeeds
+        if len(self.reaction_wheel_speeds) != 4:
+            raise ValueError(f"reactionWheelSpeeds: expected 4 elements, got {len(self.reaction_wheel_speeds)}")
+        for value in self.reaction_wheel_speeds:
+            buf += struct.pack("<h", value)
+
+        # magnetorquerCommands
+        if len(self.magnetorquer_commands) != 3:
+            raise ValueError(f"magnetorquerCommands: expected 3 elements, got {len(self.magnetorquer_commands)}")
+        for value in self.magnetorquer_commands:
+            buf += struct.pack("<h", value)
+
+        # commandTimestamp
+        buf += struct.pack("<I", self.command_timestamp)
+
+        # controlMode
+        buf += struct.pack("<B", ADCSControlMode(self.control_mode))
+
+        return bytes(buf)
+
+    @classmethod
+    def check_fingerprint(cls, fingerprint: int) -> bool:
+        """Returns whether a peer's layout fingerprint matches this build"""
+        return fingerprint == cls.FINGERPRINT
+
+    @classmethod
+    def unpack(cls, data: bytes) -> ADCSActuatorCommands:
+        """Unpacks a ADCSActuatorCommands from bytes"""
+        obj, _ = cls.unpack_from(data)
+        return obj
+
+    @classmethod
+    def unpack_from(cls, data: bytes, offset: int = 0) -> Tuple[ADCSActuatorCommands, int]:
+        """Unpacks a ADCSActuatorCommands at offset and returns it with the next offset"""
+        obj = cls()
+
+        # reactionWheelSpeeds
+        count = 4
+        obj.reaction_wheel_speeds = []
+        for _ in range(count):
+            value, offset = _unpack("<h", data, offset, "reactionWheelSpeeds")
+            obj.reaction_wheel_speeds.append(value)
+
+        # magnetorquerCommands
+        count = 3
+        obj.magnetorquer_commands = []
+        for _ in range(count):
+            value, offset = _unpack("<h", data, offset, "magnetorquerCommands")
+            obj.magnetorquer_commands.append(value)
+
+        # commandTimestamp
+        obj.command_timestamp, offset = _unpack("<I", data, offset, "commandTimestamp")
+
+        # controlMode
+        raw, offset = _unpack("<B", data, offset, "controlMode")
+        obj.control_mode = ADCSControlMode(raw)
+
+        return obj, offset
+
+
+CONTAINERS: Dict[str, Type[Any]] = {
+    "Vector3": Vector3,
+    "Quaternion": Quaternion,
+    "ADCSAttitudeState": ADCSAttitudeState,
+    "ADCSSensorData": ADCSSensorData,
+    "ADCSActuatorCommands": ADCSActuatorCommands,
+}
+"""Every container of adcs, keyed by name"""
+
+ENUMS: Dict[str, Type[IntEnum]] = {
+    "AttitudeDeterminationMode": AttitudeDeterminationMode,
+    "ADCSControlMode": ADCSControlMode,
+}
+"""Every enum of adcs, keyed by name"""
@@ -0,0 +1,670 @@
+//! adcs
+//! Generated by uscdl. Do not edit.
+
+#![allow(dead_code, clippy::identity_op, clippy::upper_case_acronyms)]
+
+/// Errors returned when encoding or decoding
+#[derive(Debug, Clone, Copy, PartialEq, Eq)]
+pub enum Error {
+    /// The buffer is too short for the encoded data
+    BufferTooSmall,
+    /// A raw value does not name a member of its enum
+    InvalidEnum,
+    /// A count or length exceeds its declared maximum
+    LengthTooLong,
+}
+
+/// Reads N bytes at off
+fn read<const N: usize>(data: &[u8], off: usize) -> Result<[u8; N], Error> {
+    let bytes = data.get(off..off + N).ok_or(Error::BufferTooSmall)?;
+    let mut out = [0u8; N];
+    out.copy_from_slice(bytes);
+    Ok(out)
+}
+
+/// Writes bytes at off
+fn write(buf: &mut [u8], off: usize, bytes: &[u8]) -> Result<(), Error> {
+    let dst = buf
+        .get_mut(off..off + bytes.len())
+        .ok_or(Error::BufferTooSmall)?;
+    dst.copy_from_slice(bytes);
+    Ok(())
+}
+
+/// Returns the length of a NUL-padded string
+fn str_len(bytes: &[u8]) -> usize {
+    bytes.iter().position(|&b| b == 0).unwrap_or(bytes.len())
+}
+
+/// Source of the current attitude solution
+#[derive(Debug, Clone, Copy, PartialEq, Eq)]
+#[repr(u8)]
+pub enum AttitudeDeterminationMode {
+    /// No attitude solution available
+    None = 0,
+    /// Coarse solution from sun sensors and magnetometer
+    SunMagnetometer = 1,
+    /// Propagated from gyroscope readings
+    Gyroscope = 2,
+    /// Fine solution from the star tracker
+    StarTracker = 3,
+}
+
+impl AttitudeDeterminationMode {
+    /// Returns the member named by a raw wire value
+    pub const fn from_raw(value: u8) -> Option<Self> {
+        match value {
+            0 => Some(Self::None),
+            1 => Some(Self::SunMagnetometer),
+            2 => Some(Self::Gyroscope),
+            3 => Some(Self::StarTracker),
+            _ => None,
+        }
+    }
+
+    /// Returns the raw wire value of the member
+    pub const fn to_raw(self) -> u8 {
+        self as u8
+    }
+}
+
+impl TryFrom<u8> for AttitudeDeterminationMode {
+    type Error = Error;
+
+    fn try_from(value: u8) -> Result<Self, Error> {
+        Self::from_raw(value).ok_or(Error::InvalidEnum)
+    }
+}
+
+/// Attitude control mode of the ADCS
+#[derive(Debug, Clone, Copy, PartialEq, Eq)]
+#[repr(u8)]
+pub enum ADCSControlMode {
+    /// Actuators disabled
+    Off = 0,
+    /// B-dot detumbling with magnetorquers
+    Detumble = 1,
+    /// Point solar arrays at the sun
+    SunPointing = 2,
+    /// Point payload at nadir
+    NadirPointing = 3,
+    /// Track a commanded ground target
+    TargetTracking = 4,
+}
+
+impl ADCSControlMode {
+    /// Returns the member named by a raw wire value
+    pub const fn from_raw(value: u8) -> Option<Self> {
+        match value {
+            0 => Some(Self::Off),
+            1 => Some(Self::Detumble),
+            2 => Some(Self::SunPointing),
+            3 => Some(Self::NadirPointing),
+            4 => Some(Self::TargetTracking),
+            _ => None,
+        }
+    }
+
+    /// Returns the raw wire value of the member
+    pub const fn to_raw(self) -> u8 {
+        self as u8
+    }
+}
+
+impl TryFrom<u8> for ADCSControlMode {
+    type Error = Error;
+
+    fn try_from(value: u8) -> Result<Self, Error> {
+        Self::from_raw(value).ok_or(Error::InvalidEnum)
+    }
+}
+
+/// Three-axis vector in the spacecraft body frame
+#[derive(Debug, Clone, Copy, PartialEq)]
+pub struct Vector3 {
+    /// X axis component
+    pub x: f32,
+    /// Y axis component
+    pub y: f32,
+    /// Z axis component
+    pub z: f32,
+}
+
+impl Vector3 {
+    /// Minimum encoded size in bytes
+    pub const MIN_SIZE: usize = 12;
+    /// Maximum encoded size in bytes
+    pub const MAX_SIZE: usize = 12;
+    /// Fingerprint of the wire layout; peers built from a different layout
+    /// have a different value
+    pub const FINGERPRINT: u32 = 0x64A0E28A;
+
+    /// Value with every field at its default
+    pub const DEFAULT: Self = Self {
+        x: 0.0,
+        y: 0.0,
+        z: 0.0,
+    };
+
+    /// Returns whether a peer's layout fingerprint matches this build
+    pub const fn check_fingerprint(fingerprint: u32) -> bool {
+        fingerprint == Self::FINGERPRINT
+    }
+
+    /// Encodes into buf and returns the number of bytes written
+    pub fn encode(&self, buf: &mut [u8]) -> Result<usize, Error> {
+        let mut off = 0;
+
+        // x
+        write(buf, off, &self.x.to_le_bytes())?;
+        off += 4;
+
+        // y
+        write(buf, off, &self.y.to_le_bytes())?;
+        off += 4;
+
+        // z
+        write(buf, off, &self.z.to_le_bytes())?;
+        off += 4;
+
+        Ok(off)
+    }
+
+    /// Decodes from the start of data
+    pub fn decode(data: &[u8]) -> Result<Self, Error> {
+        Self::decode_from(data).map(|(value, _)| value)
+    }
+
+    /// Decodes from the start of data and returns the value with the number
+    /// of bytes consumed
+    pub fn decode_from(data: &[u8]) -> Result<(Self, usize), Error> {
+        let mut value = Self::DEFAULT;
+        let mut off = 0;
+
+        // x
+        value.x = f32::from_le_bytes(read(data, off)?);
+        off += 4;
+
+        // y
+        value.y = f32::from_le_bytes(read(data, off)?);
+        off += 4;
+
+        // z
+        value.z = f32::from_le_bytes(read(data, off)?);
+        off += 4;
+
+        Ok((value, off))
+    }
+}
+
+impl Default for Vector3 {
+    fn default() -> Self {
+        Self::DEFAULT
+    }
+}
+
+/// Attitude quaternion with the scalar part last
+#[derive(Debug, Clone, Copy, PartialEq)]
+pub struct Quaternion {
+    /// First vector component
+    pub x: f32,
+    /// Second vector component
+    pub y: f32,
+    /// Third vector component
+    pub z: f32,
+    /// Scalar component
+    pub w: f32,
+}
+
+impl Quaternion {
+    /// Minimum encoded size in bytes
+    pub const MIN_SIZE: usize = 16;
+    /// Maximum encoded size in bytes
+    pub const MAX_SIZE: usize = 16;
+    /// Fingerprint of the wire layout; peers built from a different layout
+    /// have a different value
+    pub const FINGERPRINT: u32 = 0xE3D5898D;
+
+    /// Value with every field at its default
+    pub const DEFAULT: Self = Self {
+        x: 0.0,
+        y: 0.0,
+        z: 0.0,
+        w: 0.0,
+    };
+
+    /// Returns whether a peer's layout fingerprint matches this build
+    pub const fn check_fingerprint(fingerprint: u32) -> bool {
+        fingerprint == Self::FINGERPRINT
+    }
+
+    /// Encodes into buf and returns the number of bytes written
+    pub fn encode(&self, buf: &mut [u8]) -> Result<usize, Error> {
+        let mut off = 0;
+
+        // x
+        write(buf, off, &self.x.to_le_bytes())?;
+        off += 4;
+
+        // y
+        write(buf, off, &self.y.to_le_bytes())?;
+        off += 4;
+
+        // z
+        write(buf, off, &self.z.to_le_bytes())?;
+        off += 4;
+
+        // w
+        write(buf, off, &self.w.to_le_bytes())?;
+        off += 4;
+
+        Ok(off)
+    }
+
+    /// Decodes from the start of data
+    pub fn decode(data: &[u8]) -> Result<Self, Error> {
+        Self::decode_from(data).map(|(value, _)| value)
+    }
+
+    /// Decodes from the start of data and returns the value with the number
+    /// of bytes consumed
+    pub fn decode_from(data: &[u8]) -> Result<(Self, usize), Error> {
+        let mut value = Self::DEFAULT;
+        let mut off = 0;
+
+        // x
+        value.x = f32::from_le_bytes(read(data, off)?);
+        off += 4;
+
+        // y
+        value.y = f32::from_le_bytes(read(data, off)?);
+        off += 4;
+
+        // z
+        value.z = f32::from_le_bytes(read(data, off)?);
+        off += 4;
+
+        // w
+        value.w = f32::from_le_bytes(read(data, off)?);
+        off += 4;
+
+        Ok((value, off))
+    }
+}
+
+impl Default for Quaternion {
+    fn default() -> Self {
+        Self::DEFAULT
+    }
+}
+
+/// Current attitude state of the spacecraft
+#[derive(Debug, Clone, Copy, PartialEq)]
+pub struct ADCSAttitudeState {
+    /// Quaternion representing the spacecraft attitude
+    pub quaternion: Quaternion,
+    /// Angular velocity vector of the spacecraft (rad/s)
+    pub angular_velocity: Vector3,
+    /// Timestamp of the attitude measurement (ms)
+    pub timestamp: u32,
+    /// Current mode of attitude determination
+    pub attitude_determination_mode: AttitudeDeterminationMode,
+    /// Flag indicating if the attitude solution is valid
+    pub attitude_valid: bool,
+}
+
+impl ADCSAttitudeState {
+    /// Minimum encoded size in bytes
+    pub const MIN_SIZE: usize = 34;
+    /// Maximum encoded size in bytes
+    pub const MAX_SIZE: usize = 34;
+    /// Fingerprint of the wire layout; peers built from a different layout
+    /// have a different value
+    pub const FINGERPRINT: u32 = 0x93D23BF8;
+
+    /// Value with every field at its default
+    pub const DEFAULT: Self = Self {
+        quaternion: Quaternion::DEFAULT,
+        angular_velocity: Vector3::DEFAULT,
+        timestamp: 0,
+        attitude_determination_mode: AttitudeDeterminationMode::None,
+        attitude_valid: false,
+    };
+
+    /// Returns whether a peer's layout fingerprint matches this build
+    pub const fn check_fingerprint(fingerprint: u32) -> bool {
+        fingerprint == Self::FINGERPRINT
+    }
+
+    /// Encodes into buf and returns the number of bytes written
+    pub fn encode(&self, buf: &mut [u8]) -> Result<usize, Error> {
+        let mut off = 0;
+
+        // quaternion
+        off += self.quaternion.encode(&mut buf[off..])?;
+
+        // angularVelocity
+        off += self.angular_velocity.encode(&mut buf[off..])?;
+
+        // timestamp
+        write(buf, off, &self.timestamp.to_le_bytes())?;
+        off += 4;
+
+        // attitudeDeterminationMode
+        write(buf, off, &self.attitude_determination_mode.to_raw().to_le_bytes())?;
+        off += 1;
+
+        // attitudeValid
+        write(buf, off, &[self.attitude_valid as u8])?;
+        off += 1;
+
+        Ok(off)
+    }
+
+    /// Decodes from the start of data
+    pub fn decode(data: &[u8]) -> Result<Self, Error> {
+        Self::decode_from(data).map(|(value, _)| value)
+    }
+
+    /// Decodes from the start of data and returns the value with the number
+    /// of bytes consumed
+    pub fn decode_from(data: &[u8]) -> Result<(Self, usize), Error> {
+        let mut value = Self::DEFAULT;
+        let mut off = 0;
+
+        // quaternion
+        let (element, consumed) = Quaternion::decode_from(&data[off..])?;
+        value.quaternion = element;
+        off += consumed;
+
+        // angularVelocity
+        let (element, consumed) = Vector3::decode_from(&data[off..])?;
+        value.angular_velocity = element;
+        off += consumed;
+
+        // timestamp
+        value.timestamp = u32::from_le_bytes(read(data, off)?);
+        off += 4;
+
+        // attitudeDeterminationMode
+        value.attitude_determination_mode = AttitudeDeterminationMode::try_from(u8::from_le_bytes(read(data, off)?))?;
+        off += 1;
+
+        // attitudeValid
+        value.attitude_valid = read::<1>(data, off)?[0] != 0;
+        off += 1;
+
+        Ok((value, off))
+    }
+}
+
+impl Default for ADCSAttitudeState {
+    fn default() -> Self {
+        Self::DEFAULT
+    }
+}
+
+/// Raw sensor data from ADCS sensors
+#[derive(Debug, Clone, Copy, PartialEq)]
+pub struct ADCSSensorData {
+    /// Raw magnetometer readings (nT)
+    pub magnetometer_readings: [i16; 3],
+    /// Raw sun sensor readings (counts)
+    pub sun_sensor_readings: [u16; 6],
+    /// Gyroscope readings (rad/s)
+    pub gyroscope_readings: Vector3,
+    /// Timestamp of the sensor readings (ms)
+    pub sensor_timestamp: u32,
+    /// Bitmask of currently enabled sensors
+    pub sensors_enabled: u8,
+}
+
+impl ADCSSensorData {
+    /// Minimum encoded size in bytes
+    pub const MIN_SIZE: usize = 35;
+    /// Maximum encoded size in bytes
+    pub const MAX_SIZE: usize = 35;
+    /// Fingerprint of the wire layout; peers built from a different layout
+    /// have a different value
+    pub const FINGERPRINT: u32 = 0xCD3877E4;
+
+    /// Value with every field at its default
+    pub const DEFAULT: Self = Self {
+        magnetometer_readings: [0; 3],
+        sun_sensor_readings: [0; 6],
+        gyroscope_readings: Vector3::DEFAULT,
+        sensor_timestamp: 0,
+        sensors_enabled: 0,
+    };
+
+    /// Magnetometer enabled
+    pub fn sensors_enabled_magnetometer(&self) -> bool {
+        (self.sensors_enabled >> 0) & 0x1 != 0
+    }
+
+    /// Sets or clears the magnetometer bit of sensorsEnabled
+    pub fn set_sensors_enabled_magnetometer(&mut self, value: bool) {
+        self.sensors_enabled = (self.sensors_enabled & !(0x1 << 0)) | ((value as u8) << 0);
+    }
+
+    /// Sun sensors enabled
+    pub fn sensors_enabled_sun_sensors(&self) -> bool {
+        (self.sensors_enabled >> 1) & 0x1 != 0
+    }
+
+    /// Sets or clears the sunSensors bit of sensorsEnabled
+    pub fn set_sensors_enabled_sun_sensors(&mut self, value: bool) {
+        self.sensors_enabled = (self.sensors_enabled & !(0x1 << 1)) | ((value as u8) << 1);
+    }
+
+    /// Gyroscope enabled
+    pub fn sensors_enabled_gyroscope(&self) -> bool {
+        (self.sensors_enabled >> 2) & 0x1 != 0
+    }
+
+    /// Sets or clears the gyroscope bit of sensorsEnabled
+    pub fn set_sensors_enabled_gyroscope(&mut self, value: bool) {
+        self.sensors_enabled = (self.sensors_enabled & !(0x1 << 2)) | ((value as u8) << 2);
+    }
+
+    /// Star tracker enabled
+    pub fn sensors_enabled_star_tracker(&self) -> bool {
+        (self.sensors_enabled >> 3) & 0x1 != 0
+    }
+
+    /// Sets or clears the starTracker bit of sensorsEnabled
+    pub fn set_sensors_enabled_star_tracker(&mut self, value: bool) {
+        self.sensors_enabled = (self.sensors_enabled & !(0x1 << 3)) | ((value as u8) << 3);
+    }
+
+    /// Returns whether a peer's layout fingerprint matches this build
+    pub const fn check_fingerprint(fingerprint: u32) -> bool {
+        fingerprint == Self::FINGERPRINT
+    }
+
+    /// Encodes into buf and returns the number of bytes written
+    pub fn encode(&self, buf: &mut [u8]) -> Result<usize, Error> {
+        let mut off = 0;
+
+        // magnetometerReadings
+        let count = 3;
+        for i in 0..count {
+            write(buf, off, &self.magnetometer_readings[i].to_le_bytes())?;
+            off += 2;
+        }
+
+        // sunSensorReadings
+        let count = 6;
+        for i in 0..count {
+            write(buf, off, &self.sun_sensor_readings[i].to_le_bytes())?;
+            off += 2;
+        }
+
+        // gyroscopeReadings
+        off += self.gyroscope_readings.encode(&mut buf[off..])?;
+
+        // sensorTimestamp
+        write(buf, off, &self.sensor_timestamp.to_le_bytes())?;
+        off += 4;
+
+        // sensorsEnabled
+        write(buf, off, &self.sensors_enabled.to_le_bytes())?;
+        off += 1;
+
+        Ok(off)
+    }
+
+    /// Decodes from the start of data
+    pub fn decode(data: &[u8]) -> Result<Self, Error> {
+        Self::decode_from(data).map(|(value, _)| value)
+    }
+
+    /// Decodes from the start of data and returns the value with the number
+    /// of bytes consumed
+    pub fn decode_from(data: &[u8]) -> Result<(Self, usize), Error> {
+        let mut value = Self::DEFAULT;
+        let mut off = 0;
+
+        // magnetometerReadings
+        let count = 3;
+        for i in 0..count {
+            value.magnetometer_readings[i] = i16::from_le_bytes(read(data, off)?);
+            off += 2;
+        }
+
+        // sunSensorReadings
+        let count = 6;
+        for i in 0..count {
+            value.sun_sensor_readings[i] = u16::from_le_bytes(read(data, off)?);
+            off += 2;
+        }
+
+        // gyroscopeReadings
+        let (element, consumed) = Vector3::decode_from(&data[off..])?;
+        value.gyroscope_readings = element;
+        off += consumed;
+
+        // sensorTimestamp
+        value.sensor_timestamp = u32::from_le_bytes(read(data, off)?);
+        off += 4;
+
+        // sensorsEnabled
+        value.sensors_enabled = u8::from_le_bytes(read(data, off)?);
+        off += 1;
+
+        Ok((value, off))
+    }
+}
+
+impl Default for ADCSSensorData {
+    fn default() -> Self {
+        Self::DEFAULT
+    }
+}
+
+/// Commands for the attitude control actuators
+#[derive(Debug, Clone, Copy, PartialEq)]
+pub struct ADCSActuatorCommands {
+    /// Commanded reaction wheel speeds (rpm)
+    pub reaction_wheel_speeds: [i16; 4],
+    /// Commanded magnetorquer dipole moments (mA·m²)
+    pub magnetorquer_commands: [i16; 3],
+    /// Timestamp of the actuator commands (ms)
+    pub command_timestamp: u32,
+    /// Current ADCS control mode
+    pub control_mode: ADCSControlMode,
+}
+
+impl ADCSActuatorCommands {
+    /// Minimum encoded size in bytes
+    pub const MIN_SIZE: usize = 19;
+    /// Maximum encoded size in bytes
+    pub const MAX_SIZE: usize = 19;
+    /// Fingerprint of the wire layout; peers built from a different layout
+    /// have a different value
+    pub const FINGERPRINT: u32 = 0xD802194D;
+
+    /// Value with every field at its default
+    pub const DEFAULT: Self = Self {
+        reaction_wheel_speeds: [0; 4],
+        magnetorquer_commands: [0; 3],
+        command_timestamp: 0,
+        control_mode: ADCSControlMode::Off,
+    };
+
+    /// Returns whether a peer's layout fingerprint matches this build
+    pub const fn check_fingerprint(fingerprint: u32) -> bool {
+        fingerprint == Self::FINGERPRINT
+    }
+
+    /// Encodes into buf and returns the number of bytes written
+    pub fn encode(&self, buf: &mut [u8]) -> Result<usize, Error> {
+        let mut off = 0;
+
+        // reactionWheelSpeeds
+        let count = 4;
+        for i in 0..count {
+            write(buf, off, &self.reaction_wheel_speeds[i].to_le_bytes())?;
+            off += 2;
+        }
+
+        // magnetorquerCommands
+        let count = 3;
+        for i in 0..count {
+            write(buf, off, &self.magnetorquer_commands[i].to_le_bytes())?;
+            off += 2;
+        }
+
+        // commandTimestamp
+        write(buf, off, &self.command_timestamp.to_le_bytes())?;
+        off += 4;
+
+        // controlMode
+        write(buf, off, &self.control_mode.to_raw().to_le_bytes())?;
+        off += 1;
+
+        Ok(off)
+    }
+
+    /// Decodes from the start of data
+    pub fn decode(data: &[u8]) -> Result<Self, Error> {
+        Self::decode_from(data).map(|(value, _)| value)
+    }
+
+    /// Decodes from the start of data and returns the value with the number
+    /// of bytes consumed
+    pub fn decode_from(data: &[u8]) -> Result<(Self, usize), Error> {
+        let mut value = Self::DEFAULT;
+        let mut off = 0;
+
+        // reactionWheelSpeeds
+        let count = 4;
+        for i in 0..count {
+            value.reaction_wheel_speeds[i] = i16::from_le_bytes(read(data, off)?);
+            off += 2;
+        }
+
+        // magnetorquerCommands
+        let count = 3;
+        for i in 0..count {
+            value.magnetorquer_commands[i] = i16::from_le_bytes(read(data, off)?);
+            off += 2;
+        }
+
+        // commandTimestamp
+        value.command_timestamp = u32::from_le_bytes(read(data, off)?);
+        off += 4;
+
+        // controlMode
+        value.control_mode = ADCSControlMode::try_from(u8::from_le_bytes(read(data, off)?))?;
+        off += 1;
+
+        Ok((value, off))
+    }
+}
+
+impl Default for ADCSActuatorCommands {
+    fn default() -> Self {
+        Self::DEFAULT
+    }
+}
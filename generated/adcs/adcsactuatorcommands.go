@@ -0,0 +1,153 @@
+// Code generated by uscdl. DO NOT EDIT.
+
+package adcs
+
+import (
+	"encoding"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// ADCSActuatorCommands Commands for the attitude control actuators
+type ADCSActuatorCommands struct {
+	// Commanded reaction wheel speeds (rpm)
+	ReactionWheelSpeeds [4]int16 `json:"reactionWheelSpeeds" units:"rpm"`
+	// Commanded magnetorquer dipole moments (mA·m²)
+	MagnetorquerCommands [3]int16 `json:"magnetorquerCommands" units:"mA·m²"`
+	// Timestamp of the actuator commands (ms)
+	CommandTimestamp uint32 `json:"commandTimestamp" units:"ms"`
+	// Current ADCS control mode
+	ControlMode ADCSControlMode `json:"controlMode"`
+}
+
+const (
+	// ADCSActuatorCommandsMinSize is the minimum encoded size of ADCSActuatorCommands in bytes
+	ADCSActuatorCommandsMinSize = 19
+	// ADCSActuatorCommandsSize is the maximum encoded size of ADCSActuatorCommands in bytes
+	ADCSActuatorCommandsSize = 19
+	// ADCSActuatorCommandsFingerprint identifies the wire layout of ADCSActuatorCommands; peers
+	// built from a different layout have a different value
+	ADCSActuatorCommandsFingerprint uint32 = 0xD802194D
+)
+
+// CheckADCSActuatorCommandsFingerprint returns an error when a peer's fingerprint does not
+// match the ADCSActuatorCommands wire layout of this build
+func CheckADCSActuatorCommandsFingerprint(fingerprint uint32) error {
+	if fingerprint != ADCSActuatorCommandsFingerprint {
+		return fmt.Errorf("ADCSActuatorCommands layout mismatch: peer fingerprint %#08x, want %#08x", fingerprint, ADCSActuatorCommandsFingerprint)
+	}
+	return nil
+}
+
+var (
+	_ encoding.BinaryMarshaler   = (*ADCSActuatorCommands)(nil)
+	_ encoding.BinaryUnmarshaler = (*ADCSActuatorCommands)(nil)
+)
+
+// NewADCSActuatorCommands returns a ADCSActuatorCommands holding default values
+func NewADCSActuatorCommands() *ADCSActuatorCommands {
+	m := &ADCSActuatorCommands{}
+	m.ControlMode = ADCSControlModeOff
+	return m
+}
+
+// MarshalBinary encodes m into a newly allocated buffer
+func (m *ADCSActuatorCommands) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, ADCSActuatorCommandsSize)
+	n, err := m.Encode(buf)
+	if err != nil {
+		return nil, err
+	}
+	return buf[:n], nil
+}
+
+// UnmarshalBinary decodes m from data
+func (m *ADCSActuatorCommands) UnmarshalBinary(data []byte) error {
+	_, err := m.Decode(data)
+	return err
+}
+
+// Encode writes m into buf and returns the number of bytes written
+func (m *ADCSActuatorCommands) Encode(buf []byte) (int, error) {
+	off := 0
+
+	// reactionWheelSpeeds
+	for i := range m.ReactionWheelSpeeds {
+		if off+2 > len(buf) {
+			return 0, fmt.Errorf("reactionWheelSpeeds: %w", io.ErrShortBuffer)
+		}
+		binary.LittleEndian.PutUint16(buf[off:], uint16(m.ReactionWheelSpeeds[i]))
+		off += 2
+	}
+
+	// magnetorquerCommands
+	for i := range m.MagnetorquerCommands {
+		if off+2 > len(buf) {
+			return 0, fmt.Errorf("magnetorquerCommands: %w", io.ErrShortBuffer)
+		}
+		binary.LittleEndian.PutUint16(buf[off:], uint16(m.MagnetorquerCommands[i]))
+		off += 2
+	}
+
+	// commandTimestamp
+	if off+4 > len(buf) {
+		return 0, fmt.Errorf("commandTimestamp: %w", io.ErrShortBuffer)
+	}
+	binary.LittleEndian.PutUint32(buf[off:], m.CommandTimestamp)
+	off += 4
+
+	// controlMode
+	if off+1 > len(buf) {
+		return 0, fmt.Errorf("controlMode: %w", io.ErrShortBuffer)
+	}
+	if !m.ControlMode.IsValid() {
+		return 0, fmt.Errorf("controlMode: invalid ADCSControlMode %d", m.ControlMode)
+	}
+	buf[off] = uint8(m.ControlMode)
+	off += 1
+
+	return off, nil
+}
+
+// Decode reads m from data and returns the number of bytes consumed
+func (m *ADCSActuatorCommands) Decode(data []byte) (int, error) {
+	off := 0
+
+	// reactionWheelSpeeds
+	for i := range m.ReactionWheelSpeeds {
+		if off+2 > len(data) {
+			return 0, fmt.Errorf("reactionWheelSpeeds: %w", io.ErrUnexpectedEOF)
+		}
+		m.ReactionWheelSpeeds[i] = int16(binary.LittleEndian.Uint16(data[off:]))
+		off += 2
+	}
+
+	// magnetorquerCommands
+	for i := range m.MagnetorquerCommands {
+		if off+2 > len(data) {
+			return 0, fmt.Errorf("magnetorquerCommands: %w", io.ErrUnexpectedEOF)
+		}
+		m.MagnetorquerCommands[i] = int16(binary.LittleEndian.Uint16(data[off:]))
+		off += 2
+	}
+
+	// commandTimestamp
+	if off+4 > len(data) {
+		return 0, fmt.Errorf("commandTimestamp: %w", io.ErrUnexpectedEOF)
+	}
+	m.CommandTimestamp = binary.LittleEndian.Uint32(data[off:])
+	off += 4
+
+	// controlMode
+	if off+1 > len(data) {
+		return 0, fmt.Errorf("controlMode: %w", io.ErrUnexpectedEOF)
+	}
+	m.ControlMode = ADCSControlMode(data[off])
+	if !m.ControlMode.IsValid() {
+		return 0, fmt.Errorf("controlMode: invalid ADCSControlMode %d", m.ControlMode)
+	}
+	off += 1
+
+	return off, nil
+}
@@ -0,0 +1,174 @@
+// Code generated by uscdl. DO NOT EDIT.
+
+package adcs
+
+import (
+	"encoding"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// ADCSAttitudeState Current attitude state of the spacecraft
+type ADCSAttitudeState struct {
+	// Quaternion representing the spacecraft attitude
+	Quaternion Quaternion `json:"quaternion"`
+	// Angular velocity vector of the spacecraft (rad/s)
+	AngularVelocity Vector3 `json:"angularVelocity" units:"rad/s"`
+	// Timestamp of the attitude measurement (ms)
+	Timestamp uint32 `json:"timestamp" units:"ms"`
+	// Current mode of attitude determination
+	AttitudeDeterminationMode AttitudeDeterminationMode `json:"attitudeDeterminationMode"`
+	// Flag indicating if the attitude solution is valid
+	AttitudeValid bool `json:"attitudeValid"`
+}
+
+const (
+	// ADCSAttitudeStateMinSize is the minimum encoded size of ADCSAttitudeState in bytes
+	ADCSAttitudeStateMinSize = 34
+	// ADCSAttitudeStateSize is the maximum encoded size of ADCSAttitudeState in bytes
+	ADCSAttitudeStateSize = 34
+	// ADCSAttitudeStateFingerprint identifies the wire layout of ADCSAttitudeState; peers
+	// built from a different layout have a different value
+	ADCSAttitudeStateFingerprint uint32 = 0x93D23BF8
+)
+
+// CheckADCSAttitudeStateFingerprint returns an error when a peer's fingerprint does not
+// match the ADCSAttitudeState wire layout of this build
+func CheckADCSAttitudeStateFingerprint(fingerprint uint32) error {
+	if fingerprint != ADCSAttitudeStateFingerprint {
+		return fmt.Errorf("ADCSAttitudeState layout mismatch: peer fingerprint %#08x, want %#08x", fingerprint, ADCSAttitudeStateFingerprint)
+	}
+	return nil
+}
+
+var (
+	_ encoding.BinaryMarshaler   = (*ADCSAttitudeState)(nil)
+	_ encoding.BinaryUnmarshaler = (*ADCSAttitudeState)(nil)
+)
+
+// NewADCSAttitudeState returns a ADCSAttitudeState holding default values
+func NewADCSAttitudeState() *ADCSAttitudeState {
+	m := &ADCSAttitudeState{}
+	m.Quaternion = *NewQuaternion()
+	m.AngularVelocity = *NewVector3()
+	m.AttitudeDeterminationMode = AttitudeDeterminationModeNone
+	return m
+}
+
+// MarshalBinary encodes m into a newly allocated buffer
+func (m *ADCSAttitudeState) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, ADCSAttitudeStateSize)
+	n, err := m.Encode(buf)
+	if err != nil {
+		return nil, err
+	}
+	return buf[:n], nil
+}
+
+// UnmarshalBinary decodes m from data
+func (m *ADCSAttitudeState) UnmarshalBinary(data []byte) error {
+	_, err := m.Decode(data)
+	return err
+}
+
+// Encode writes m into buf and returns the number of bytes written
+func (m *ADCSAttitudeState) Encode(buf []byte) (int, error) {
+	off := 0
+
+	// quaternion
+	{
+		n, err := m.Quaternion.Encode(buf[off:])
+		if err != nil {
+			return 0, fmt.Errorf("quaternion: %w", err)
+		}
+		off += n
+	}
+
+	// angularVelocity
+	{
+		n, err := m.AngularVelocity.Encode(buf[off:])
+		if err != nil {
+			return 0, fmt.Errorf("angularVelocity: %w", err)
+		}
+		off += n
+	}
+
+	// timestamp
+	if off+4 > len(buf) {
+		return 0, fmt.Errorf("timestamp: %w", io.ErrShortBuffer)
+	}
+	binary.LittleEndian.PutUint32(buf[off:], m.Timestamp)
+	off += 4
+
+	// attitudeDeterminationMode
+	if off+1 > len(buf) {
+		return 0, fmt.Errorf("attitudeDeterminationMode: %w", io.ErrShortBuffer)
+	}
+	if !m.AttitudeDeterminationMode.IsValid() {
+		return 0, fmt.Errorf("attitudeDeterminationMode: invalid AttitudeDeterminationMode %d", m.AttitudeDeterminationMode)
+	}
+	buf[off] = uint8(m.AttitudeDeterminationMode)
+	off += 1
+
+	// attitudeValid
+	if off+1 > len(buf) {
+		return 0, fmt.Errorf("attitudeValid: %w", io.ErrShortBuffer)
+	}
+	buf[off] = 0
+	if m.AttitudeValid {
+		buf[off] = 1
+	}
+	off += 1
+
+	return off, nil
+}
+
+// Decode reads m from data and returns the number of bytes consumed
+func (m *ADCSAttitudeState) Decode(data []byte) (int, error) {
+	off := 0
+
+	// quaternion
+	{
+		n, err := m.Quaternion.Decode(data[off:])
+		if err != nil {
+			return 0, fmt.Errorf("quaternion: %w", err)
+		}
+		off += n
+	}
+
+	// angularVelocity
+	{
+		n, err := m.AngularVelocity.Decode(data[off:])
+		if err != nil {
+			return 0, fmt.Errorf("angularVelocity: %w", err)
+		}
+		off += n
+	}
+
+	// timestamp
+	if off+4 > len(data) {
+		return 0, fmt.Errorf("timestamp: %w", io.ErrUnexpectedEOF)
+	}
+	m.Timestamp = binary.LittleEndian.Uint32(data[off:])
+	off += 4
+
+	// attitudeDeterminationMode
+	if off+1 > len(data) {
+		return 0, fmt.Errorf("attitudeDeterminationMode: %w", io.ErrUnexpectedEOF)
+	}
+	m.AttitudeDeterminationMode = AttitudeDeterminationMode(data[off])
+	if !m.AttitudeDeterminationMode.IsValid() {
+		return 0, fmt.Errorf("attitudeDeterminationMode: invalid AttitudeDeterminationMode %d", m.AttitudeDeterminationMode)
+	}
+	off += 1
+
+	// attitudeValid
+	if off+1 > len(data) {
+		return 0, fmt.Errorf("attitudeValid: %w", io.ErrUnexpectedEOF)
+	}
+	m.AttitudeValid = data[off] != 0
+	off += 1
+
+	return off, nil
+}
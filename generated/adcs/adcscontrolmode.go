@@ -0,0 +1,47 @@
+// Code generated by uscdl. DO NOT EDIT.
+
+package adcs
+
+import "fmt"
+
+// ADCSControlMode Attitude control mode of the ADCS
+type ADCSControlMode uint8
+
+const (
+	// ADCSControlModeOff Actuators disabled
+	ADCSControlModeOff ADCSControlMode = 0
+	// ADCSControlModeDetumble B-dot detumbling with magnetorquers
+	ADCSControlModeDetumble ADCSControlMode = 1
+	// ADCSControlModeSunPointing Point solar arrays at the sun
+	ADCSControlModeSunPointing ADCSControlMode = 2
+	// ADCSControlModeNadirPointing Point payload at nadir
+	ADCSControlModeNadirPointing ADCSControlMode = 3
+	// ADCSControlModeTargetTracking Track a commanded ground target
+	ADCSControlModeTargetTracking ADCSControlMode = 4
+)
+
+// IsValid reports whether v names a ADCSControlMode member
+func (v ADCSControlMode) IsValid() bool {
+	switch v {
+	case ADCSControlModeOff, ADCSControlModeDetumble, ADCSControlModeSunPointing, ADCSControlModeNadirPointing, ADCSControlModeTargetTracking:
+		return true
+	}
+	return false
+}
+
+// String returns the name of v
+func (v ADCSControlMode) String() string {
+	switch v {
+	case ADCSControlModeOff:
+		return "Off"
+	case ADCSControlModeDetumble:
+		return "Detumble"
+	case ADCSControlModeSunPointing:
+		return "SunPointing"
+	case ADCSControlModeNadirPointing:
+		return "NadirPointing"
+	case ADCSControlModeTargetTracking:
+		return "TargetTracking"
+	}
+	return fmt.Sprintf("ADCSControlMode(%d)", uint8(v))
+}
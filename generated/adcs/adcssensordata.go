@@ -0,0 +1,219 @@
+// Code generated by uscdl. DO NOT EDIT.
+
+package adcs
+
+import (
+	"encoding"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// ADCSSensorData Raw sensor data from ADCS sensors
+type ADCSSensorData struct {
+	// Raw magnetometer readings (nT)
+	MagnetometerReadings [3]int16 `json:"magnetometerReadings" units:"nT"`
+	// Raw sun sensor readings (counts)
+	SunSensorReadings [6]uint16 `json:"sunSensorReadings" units:"counts"`
+	// Gyroscope readings (rad/s)
+	GyroscopeReadings Vector3 `json:"gyroscopeReadings" units:"rad/s"`
+	// Timestamp of the sensor readings (ms)
+	SensorTimestamp uint32 `json:"sensorTimestamp" units:"ms"`
+	// Bitmask of currently enabled sensors
+	SensorsEnabled uint8 `json:"sensorsEnabled"`
+}
+
+const (
+	// ADCSSensorDataMinSize is the minimum encoded size of ADCSSensorData in bytes
+	ADCSSensorDataMinSize = 35
+	// ADCSSensorDataSize is the maximum encoded size of ADCSSensorData in bytes
+	ADCSSensorDataSize = 35
+	// ADCSSensorDataFingerprint identifies the wire layout of ADCSSensorData; peers
+	// built from a different layout have a different value
+	ADCSSensorDataFingerprint uint32 = 0xCD3877E4
+)
+
+// CheckADCSSensorDataFingerprint returns an error when a peer's fingerprint does not
+// match the ADCSSensorData wire layout of this build
+func CheckADCSSensorDataFingerprint(fingerprint uint32) error {
+	if fingerprint != ADCSSensorDataFingerprint {
+		return fmt.Errorf("ADCSSensorData layout mismatch: peer fingerprint %#08x, want %#08x", fingerprint, ADCSSensorDataFingerprint)
+	}
+	return nil
+}
+
+var (
+	_ encoding.BinaryMarshaler   = (*ADCSSensorData)(nil)
+	_ encoding.BinaryUnmarshaler = (*ADCSSensorData)(nil)
+)
+
+// NewADCSSensorData returns a ADCSSensorData holding default values
+func NewADCSSensorData() *ADCSSensorData {
+	m := &ADCSSensorData{}
+	m.GyroscopeReadings = *NewVector3()
+	return m
+}
+
+// SensorsEnabledMagnetometer reports whether the magnetometer bit of sensorsEnabled is set
+func (m *ADCSSensorData) SensorsEnabledMagnetometer() bool {
+	return (m.SensorsEnabled>>0)&0x1 != 0
+}
+
+// SetSensorsEnabledMagnetometer sets or clears the magnetometer bit of sensorsEnabled
+func (m *ADCSSensorData) SetSensorsEnabledMagnetometer(v bool) {
+	m.SensorsEnabled &^= 0x1 << 0
+	if v {
+		m.SensorsEnabled |= 0x1 << 0
+	}
+}
+
+// SensorsEnabledSunSensors reports whether the sunSensors bit of sensorsEnabled is set
+func (m *ADCSSensorData) SensorsEnabledSunSensors() bool {
+	return (m.SensorsEnabled>>1)&0x1 != 0
+}
+
+// SetSensorsEnabledSunSensors sets or clears the sunSensors bit of sensorsEnabled
+func (m *ADCSSensorData) SetSensorsEnabledSunSensors(v bool) {
+	m.SensorsEnabled &^= 0x1 << 1
+	if v {
+		m.SensorsEnabled |= 0x1 << 1
+	}
+}
+
+// SensorsEnabledGyroscope reports whether the gyroscope bit of sensorsEnabled is set
+func (m *ADCSSensorData) SensorsEnabledGyroscope() bool {
+	return (m.SensorsEnabled>>2)&0x1 != 0
+}
+
+// SetSensorsEnabledGyroscope sets or clears the gyroscope bit of sensorsEnabled
+func (m *ADCSSensorData) SetSensorsEnabledGyroscope(v bool) {
+	m.SensorsEnabled &^= 0x1 << 2
+	if v {
+		m.SensorsEnabled |= 0x1 << 2
+	}
+}
+
+// SensorsEnabledStarTracker reports whether the starTracker bit of sensorsEnabled is set
+func (m *ADCSSensorData) SensorsEnabledStarTracker() bool {
+	return (m.SensorsEnabled>>3)&0x1 != 0
+}
+
+// SetSensorsEnabledStarTracker sets or clears the starTracker bit of sensorsEnabled
+func (m *ADCSSensorData) SetSensorsEnabledStarTracker(v bool) {
+	m.SensorsEnabled &^= 0x1 << 3
+	if v {
+		m.SensorsEnabled |= 0x1 << 3
+	}
+}
+
+// MarshalBinary encodes m into a newly allocated buffer
+func (m *ADCSSensorData) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, ADCSSensorDataSize)
+	n, err := m.Encode(buf)
+	if err != nil {
+		return nil, err
+	}
+	return buf[:n], nil
+}
+
+// UnmarshalBinary decodes m from data
+func (m *ADCSSensorData) UnmarshalBinary(data []byte) error {
+	_, err := m.Decode(data)
+	return err
+}
+
+// Encode writes m into buf and returns the number of bytes written
+func (m *ADCSSensorData) Encode(buf []byte) (int, error) {
+	off := 0
+
+	// magnetometerReadings
+	for i := range m.MagnetometerReadings {
+		if off+2 > len(buf) {
+			return 0, fmt.Errorf("magnetometerReadings: %w", io.ErrShortBuffer)
+		}
+		binary.LittleEndian.PutUint16(buf[off:], uint16(m.MagnetometerReadings[i]))
+		off += 2
+	}
+
+	// sunSensorReadings
+	for i := range m.SunSensorReadings {
+		if off+2 > len(buf) {
+			return 0, fmt.Errorf("sunSensorReadings: %w", io.ErrShortBuffer)
+		}
+		binary.LittleEndian.PutUint16(buf[off:], m.SunSensorReadings[i])
+		off += 2
+	}
+
+	// gyroscopeReadings
+	{
+		n, err := m.GyroscopeReadings.Encode(buf[off:])
+		if err != nil {
+			return 0, fmt.Errorf("gyroscopeReadings: %w", err)
+		}
+		off += n
+	}
+
+	// sensorTimestamp
+	if off+4 > len(buf) {
+		return 0, fmt.Errorf("sensorTimestamp: %w", io.ErrShortBuffer)
+	}
+	binary.LittleEndian.PutUint32(buf[off:], m.SensorTimestamp)
+	off += 4
+
+	// sensorsEnabled
+	if off+1 > len(buf) {
+		return 0, fmt.Errorf("sensorsEnabled: %w", io.ErrShortBuffer)
+	}
+	buf[off] = m.SensorsEnabled
+	off += 1
+
+	return off, nil
+}
+
+// Decode reads m from data and returns the number of bytes consumed
+func (m *ADCSSensorData) Decode(data []byte) (int, error) {
+	off := 0
+
+	// magnetometerReadings
+	for i := range m.MagnetometerReadings {
+		if off+2 > len(data) {
+			return 0, fmt.Errorf("magnetometerReadings: %w", io.ErrUnexpectedEOF)
+		}
+		m.MagnetometerReadings[i] = int16(binary.LittleEndian.Uint16(data[off:]))
+		off += 2
+	}
+
+	// sunSensorReadings
+	for i := range m.SunSensorReadings {
+		if off+2 > len(data) {
+			return 0, fmt.Errorf("sunSensorReadings: %w", io.ErrUnexpectedEOF)
+		}
+		m.SunSensorReadings[i] = binary.LittleEndian.Uint16(data[off:])
+		off += 2
+	}
+
+	// gyroscopeReadings
+	{
+		n, err := m.GyroscopeReadings.Decode(data[off:])
+		if err != nil {
+			return 0, fmt.Errorf("gyroscopeReadings: %w", err)
+		}
+		off += n
+	}
+
+	// sensorTimestamp
+	if off+4 > len(data) {
+		return 0, fmt.Errorf("sensorTimestamp: %w", io.ErrUnexpectedEOF)
+	}
+	m.SensorTimestamp = binary.LittleEndian.Uint32(data[off:])
+	off += 4
+
+	// sensorsEnabled
+	if off+1 > len(data) {
+		return 0, fmt.Errorf("sensorsEnabled: %w", io.ErrUnexpectedEOF)
+	}
+	m.SensorsEnabled = data[off]
+	off += 1
+
+	return off, nil
+}
@@ -0,0 +1,43 @@
+// Code generated by uscdl. DO NOT EDIT.
+
+package adcs
+
+import "fmt"
+
+// AttitudeDeterminationMode Source of the current attitude solution
+type AttitudeDeterminationMode uint8
+
+const (
+	// AttitudeDeterminationModeNone No attitude solution available
+	AttitudeDeterminationModeNone AttitudeDeterminationMode = 0
+	// AttitudeDeterminationModeSunMagnetometer Coarse solution from sun sensors and magnetometer
+	AttitudeDeterminationModeSunMagnetometer AttitudeDeterminationMode = 1
+	// AttitudeDeterminationModeGyroscope Propagated from gyroscope readings
+	AttitudeDeterminationModeGyroscope AttitudeDeterminationMode = 2
+	// AttitudeDeterminationModeStarTracker Fine solution from the star tracker
+	AttitudeDeterminationModeStarTracker AttitudeDeterminationMode = 3
+)
+
+// IsValid reports whether v names a AttitudeDeterminationMode member
+func (v AttitudeDeterminationMode) IsValid() bool {
+	switch v {
+	case AttitudeDeterminationModeNone, AttitudeDeterminationModeSunMagnetometer, AttitudeDeterminationModeGyroscope, AttitudeDeterminationModeStarTracker:
+		return true
+	}
+	return false
+}
+
+// String returns the name of v
+func (v AttitudeDeterminationMode) String() string {
+	switch v {
+	case AttitudeDeterminationModeNone:
+		return "None"
+	case AttitudeDeterminationModeSunMagnetometer:
+		return "SunMagnetometer"
+	case AttitudeDeterminationModeGyroscope:
+		return "Gyroscope"
+	case AttitudeDeterminationModeStarTracker:
+		return "StarTracker"
+	}
+	return fmt.Sprintf("AttitudeDeterminationMode(%d)", uint8(v))
+}
@@ -0,0 +1,139 @@
+// Code generated by uscdl. DO NOT EDIT.
+
+package adcs
+
+import (
+	"encoding"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+// Quaternion Attitude quaternion with the scalar part last
+type Quaternion struct {
+	// First vector component
+	X float32 `json:"x"`
+	// Second vector component
+	Y float32 `json:"y"`
+	// Third vector component
+	Z float32 `json:"z"`
+	// Scalar component
+	W float32 `json:"w"`
+}
+
+const (
+	// QuaternionMinSize is the minimum encoded size of Quaternion in bytes
+	QuaternionMinSize = 16
+	// QuaternionSize is the maximum encoded size of Quaternion in bytes
+	QuaternionSize = 16
+	// QuaternionFingerprint identifies the wire layout of Quaternion; peers
+	// built from a different layout have a different value
+	QuaternionFingerprint uint32 = 0xE3D5898D
+)
+
+// CheckQuaternionFingerprint returns an error when a peer's fingerprint does not
+// match the Quaternion wire layout of this build
+func CheckQuaternionFingerprint(fingerprint uint32) error {
+	if fingerprint != QuaternionFingerprint {
+		return fmt.Errorf("Quaternion layout mismatch: peer fingerprint %#08x, want %#08x", fingerprint, QuaternionFingerprint)
+	}
+	return nil
+}
+
+var (
+	_ encoding.BinaryMarshaler   = (*Quaternion)(nil)
+	_ encoding.BinaryUnmarshaler = (*Quaternion)(nil)
+)
+
+// NewQuaternion returns a Quaternion holding default values
+func NewQuaternion() *Quaternion {
+	m := &Quaternion{}
+	return m
+}
+
+// MarshalBinary encodes m into a newly allocated buffer
+func (m *Quaternion) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, QuaternionSize)
+	n, err := m.Encode(buf)
+	if err != nil {
+		return nil, err
+	}
+	return buf[:n], nil
+}
+
+// UnmarshalBinary decodes m from data
+func (m *Quaternion) UnmarshalBinary(data []byte) error {
+	_, err := m.Decode(data)
+	return err
+}
+
+// Encode writes m into buf and returns the number of bytes written
+func (m *Quaternion) Encode(buf []byte) (int, error) {
+	off := 0
+
+	// x
+	if off+4 > len(buf) {
+		return 0, fmt.Errorf("x: %w", io.ErrShortBuffer)
+	}
+	binary.LittleEndian.PutUint32(buf[off:], math.Float32bits(m.X))
+	off += 4
+
+	// y
+	if off+4 > len(buf) {
+		return 0, fmt.Errorf("y: %w", io.ErrShortBuffer)
+	}
+	binary.LittleEndian.PutUint32(buf[off:], math.Float32bits(m.Y))
+	off += 4
+
+	// z
+	if off+4 > len(buf) {
+		return 0, fmt.Errorf("z: %w", io.ErrShortBuffer)
+	}
+	binary.LittleEndian.PutUint32(buf[off:], math.Float32bits(m.Z))
+	off += 4
+
+	// w
+	if off+4 > len(buf) {
+		return 0, fmt.Errorf("w: %w", io.ErrShortBuffer)
+	}
+	binary.LittleEndian.PutUint32(buf[off:], math.Float32bits(m.W))
+	off += 4
+
+	return off, nil
+}
+
+// Decode reads m from data and returns the number of bytes consumed
+func (m *Quaternion) Decode(data []byte) (int, error) {
+	off := 0
+
+	// x
+	if off+4 > len(data) {
+		return 0, fmt.Errorf("x: %w", io.ErrUnexpectedEOF)
+	}
+	m.X = math.Float32frombits(binary.LittleEndian.Uint32(data[off:]))
+	off += 4
+
+	// y
+	if off+4 > len(data) {
+		return 0, fmt.Errorf("y: %w", io.ErrUnexpectedEOF)
+	}
+	m.Y = math.Float32frombits(binary.LittleEndian.Uint32(data[off:]))
+	off += 4
+
+	// z
+	if off+4 > len(data) {
+		return 0, fmt.Errorf("z: %w", io.ErrUnexpectedEOF)
+	}
+	m.Z = math.Float32frombits(binary.LittleEndian.Uint32(data[off:]))
+	off += 4
+
+	// w
+	if off+4 > len(data) {
+		return 0, fmt.Errorf("w: %w", io.ErrUnexpectedEOF)
+	}
+	m.W = math.Float32frombits(binary.LittleEndian.Uint32(data[off:]))
+	off += 4
+
+	return off, nil
+}
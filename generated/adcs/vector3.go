@@ -0,0 +1,123 @@
+// Code generated by uscdl. DO NOT EDIT.
+
+package adcs
+
+import (
+	"encoding"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+// Vector3 Three-axis vector in the spacecraft body frame
+type Vector3 struct {
+	// X axis component
+	X float32 `json:"x"`
+	// Y axis component
+	Y float32 `json:"y"`
+	// Z axis component
+	Z float32 `json:"z"`
+}
+
+const (
+	// Vector3MinSize is the minimum encoded size of Vector3 in bytes
+	Vector3MinSize = 12
+	// Vector3Size is the maximum encoded size of Vector3 in bytes
+	Vector3Size = 12
+	// Vector3Fingerprint identifies the wire layout of Vector3; peers
+	// built from a different layout have a different value
+	Vector3Fingerprint uint32 = 0x64A0E28A
+)
+
+// CheckVector3Fingerprint returns an error when a peer's fingerprint does not
+// match the Vector3 wire layout of this build
+func CheckVector3Fingerprint(fingerprint uint32) error {
+	if fingerprint != Vector3Fingerprint {
+		return fmt.Errorf("Vector3 layout mismatch: peer fingerprint %#08x, want %#08x", fingerprint, Vector3Fingerprint)
+	}
+	return nil
+}
+
+var (
+	_ encoding.BinaryMarshaler   = (*Vector3)(nil)
+	_ encoding.BinaryUnmarshaler = (*Vector3)(nil)
+)
+
+// NewVector3 returns a Vector3 holding default values
+func NewVector3() *Vector3 {
+	m := &Vector3{}
+	return m
+}
+
+// MarshalBinary encodes m into a newly allocated buffer
+func (m *Vector3) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, Vector3Size)
+	n, err := m.Encode(buf)
+	if err != nil {
+		return nil, err
+	}
+	return buf[:n], nil
+}
+
+// UnmarshalBinary decodes m from data
+func (m *Vector3) UnmarshalBinary(data []byte) error {
+	_, err := m.Decode(data)
+	return err
+}
+
+// Encode writes m into buf and returns the number of bytes written
+func (m *Vector3) Encode(buf []byte) (int, error) {
+	off := 0
+
+	// x
+	if off+4 > len(buf) {
+		return 0, fmt.Errorf("x: %w", io.ErrShortBuffer)
+	}
+	binary.LittleEndian.PutUint32(buf[off:], math.Float32bits(m.X))
+	off += 4
+
+	// y
+	if off+4 > len(buf) {
+		return 0, fmt.Errorf("y: %w", io.ErrShortBuffer)
+	}
+	binary.LittleEndian.PutUint32(buf[off:], math.Float32bits(m.Y))
+	off += 4
+
+	// z
+	if off+4 > len(buf) {
+		return 0, fmt.Errorf("z: %w", io.ErrShortBuffer)
+	}
+	binary.LittleEndian.PutUint32(buf[off:], math.Float32bits(m.Z))
+	off += 4
+
+	return off, nil
+}
+
+// Decode reads m from data and returns the number of bytes consumed
+func (m *Vector3) Decode(data []byte) (int, error) {
+	off := 0
+
+	// x
+	if off+4 > len(data) {
+		return 0, fmt.Errorf("x: %w", io.ErrUnexpectedEOF)
+	}
+	m.X = math.Float32frombits(binary.LittleEndian.Uint32(data[off:]))
+	off += 4
+
+	// y
+	if off+4 > len(data) {
+		return 0, fmt.Errorf("y: %w", io.ErrUnexpectedEOF)
+	}
+	m.Y = math.Float32frombits(binary.LittleEndian.Uint32(data[off:]))
+	off += 4
+
+	// z
+	if off+4 > len(data) {
+		return 0, fmt.Errorf("z: %w", io.ErrUnexpectedEOF)
+	}
+	m.Z = math.Float32frombits(binary.LittleEndian.Uint32(data[off:]))
+	off += 4
+
+	return off, nil
+}
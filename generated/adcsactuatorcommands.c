@@ -4,8 +4,8 @@
 */
 
 #include "adcsactuatorcommands.h"
+#include "uscdl_endian.h"
 #include <string.h>
-#include <stdlib.h>
 
 void adcs_actuator_commands_init(ADCSActuatorCommands_t* p_data) {
     if (p_data == NULL) {
@@ -14,7 +14,7 @@ void adcs_actuator_commands_init(ADCSActuatorCommands_t* p_data) {
     memset(p_data->reactionWheelSpeeds, 0, sizeof(p_data->reactionWheelSpeeds));
     memset(p_data->magnetorquerCommands, 0, sizeof(p_data->magnetorquerCommands));
     p_data->commandTimestamp = 0;
-    p_data->controlMode = 0;
+    p_data->controlMode = ADCS_CONTROL_MODE_OFF;
 }
 
 int adcs_actuator_commands_serialize(const ADCSActuatorCommands_t* p_data, uint8_t* buffer, size_t buffer_size) {
@@ -22,27 +22,42 @@ int adcs_actuator_commands_serialize(const ADCSActuatorCommands_t* p_data, uint8
         return -1;
     }
 
-    // Ensure buffer is large enough
-    if (buffer_size < 19) {
-        return -1;
-    }
-
     size_t offset = 0;
     uint8_t* ptr = buffer;
-    size_t item_size = 0;
-    // Direct copy for little-endian or byte types
-    item_size = 2 * 4;
-    memcpy(ptr + offset, p_data->reactionWheelSpeeds, item_size);
-    offset += item_size;
-    // Direct copy for little-endian or byte types
-    item_size = 2 * 3;
-    memcpy(ptr + offset, p_data->magnetorquerCommands, item_size);
-    offset += item_size;
-    // Direct copy for little-endian or byte types
-    memcpy(ptr + offset, &p_data->commandTimestamp, 4);
+
+    // reactionWheelSpeeds: 4 elements
+    for (size_t i = 0; i < 4; i++) {
+        if (offset + 2 > buffer_size) {
+            return -1;
+        }
+        uscdl_put_i16_le(ptr + offset, p_data->reactionWheelSpeeds[i]);
+        offset += 2;
+    }
+
+    // magnetorquerCommands: 3 elements
+    for (size_t i = 0; i < 3; i++) {
+        if (offset + 2 > buffer_size) {
+            return -1;
+        }
+        uscdl_put_i16_le(ptr + offset, p_data->magnetorquerCommands[i]);
+        offset += 2;
+    }
+
+    // commandTimestamp
+    if (offset + 4 > buffer_size) {
+        return -1;
+    }
+    uscdl_put_u32_le(ptr + offset, p_data->commandTimestamp);
     offset += 4;
-    // Direct copy for little-endian or byte types
-    memcpy(ptr + offset, &p_data->controlMode, 1);
+
+    // controlMode
+    if (offset + 1 > buffer_size) {
+        return -1;
+    }
+    if (!adcs_control_mode_is_valid((uint8_t)p_data->controlMode)) {
+        return -1;
+    }
+    uscdl_put_u8(ptr + offset, (uint8_t)p_data->controlMode);
     offset += 1;
 
     return (int)offset;
@@ -58,32 +73,43 @@ int adcs_actuator_commands_deserialize(ADCSActuatorCommands_t* p_data, const uin
 
     size_t offset = 0;
     const uint8_t* ptr = buffer;
-    size_t item_size = 0;
-    // Direct copy for little-endian or byte types
-    item_size = 2 * 4;
-    if (offset + item_size > buffer_size) {
-        return -1;
+
+    // reactionWheelSpeeds: 4 elements
+    for (size_t i = 0; i < 4; i++) {
+        if (offset + 2 > buffer_size) {
+            return -1;
+        }
+        p_data->reactionWheelSpeeds[i] = uscdl_get_i16_le(ptr + offset);
+        offset += 2;
     }
-    memcpy(p_data->reactionWheelSpeeds, ptr + offset, item_size);
-    offset += item_size;
-    // Direct copy for little-endian or byte types
-    item_size = 2 * 3;
-    if (offset + item_size > buffer_size) {
-        return -1;
+
+    // magnetorquerCommands: 3 elements
+    for (size_t i = 0; i < 3; i++) {
+        if (offset + 2 > buffer_size) {
+            return -1;
+        }
+        p_data->magnetorquerCommands[i] = uscdl_get_i16_le(ptr + offset);
+        offset += 2;
     }
-    memcpy(p_data->magnetorquerCommands, ptr + offset, item_size);
-    offset += item_size;
-    // Direct copy for little-endian or byte types
+
+    // commandTimestamp
     if (offset + 4 > buffer_size) {
         return -1;
     }
-    memcpy(&p_data->commandTimestamp, ptr + offset, 4);
+    p_data->commandTimestamp = uscdl_get_u32_le(ptr + offset);
     offset += 4;
-    // Direct copy for little-endian or byte types
+
+    // controlMode
     if (offset + 1 > buffer_size) {
         return -1;
     }
-    memcpy(&p_data->controlMode, ptr + offset, 1);
+    {
+        uint8_t raw = uscdl_get_u8(ptr + offset);
+        if (!adcs_control_mode_is_valid(raw)) {
+            return -1;
+        }
+        p_data->controlMode = (ADCSControlMode_t)raw;
+    }
     offset += 1;
 
     return (int)offset;
@@ -8,6 +8,8 @@
 
 #include <stdint.h>
   #include <stdbool.h>
+  #include <stddef.h>
+  #include "adcscontrolmode.h"
 
     /**
     * Commands for the attitude control actuators
@@ -19,15 +21,49 @@
     int16_t magnetorquerCommands[3];
     /* Timestamp of the actuator commands (ms) */
     uint32_t commandTimestamp;
-    /* Current ADCS control mode (enum) */
-    uint8_t controlMode;
+    /* Current ADCS control mode */
+    ADCSControlMode_t controlMode;
     } ADCSActuatorCommands_t;
 
+    /* Serialized size bounds of ADCSActuatorCommands in bytes */
+    #define ADCS_ACTUATOR_COMMANDS_MIN_SIZE 19
+    #define ADCS_ACTUATOR_COMMANDS_MAX_SIZE 19
+
+    /* Fingerprint of the wire layout of ADCSActuatorCommands; peers built from a different layout have a different value */
+    #define ADCS_ACTUATOR_COMMANDS_FINGERPRINT 0xD802194Du
+
     /**
     * Initialize a ADCSActuatorCommands structure with default values
     * @param p_data Pointer to the structure to initialize
     */
     void adcs_actuator_commands_init(ADCSActuatorCommands_t* p_data);
 
+    /**
+    * Serialize a ADCSActuatorCommands structure into a buffer
+    * @param p_data Pointer to the structure to serialize
+    * @param buffer Destination buffer
+    * @param buffer_size Size of the destination buffer in bytes
+    * @return Number of bytes written, or -1 on error
+    */
+    int adcs_actuator_commands_serialize(const ADCSActuatorCommands_t* p_data, uint8_t* buffer, size_t buffer_size);
+
+    /**
+    * Deserialize a ADCSActuatorCommands structure from a buffer
+    * @param p_data Pointer to the structure to fill
+    * @param buffer Source buffer
+    * @param buffer_size Size of the source buffer in bytes
+    * @return Number of bytes read, or -1 on error
+    */
+    int adcs_actuator_commands_deserialize(ADCSActuatorCommands_t* p_data, const uint8_t* buffer, size_t buffer_size);
+
+    /**
+    * Check a peer's ADCSActuatorCommands layout fingerprint against this build
+    * @param fingerprint The fingerprint reported by the peer
+    * @return true if both sides use the same wire layout
+    */
+    static inline bool adcs_actuator_commands_check_fingerprint(uint32_t fingerprint) {
+        return fingerprint == ADCS_ACTUATOR_COMMANDS_FINGERPRINT;
+    }
+
     #endif /* ADCSACTUATORCOMMANDS_H */
     
\ No newline at end of file
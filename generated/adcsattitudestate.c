@@ -4,17 +4,17 @@
 */
 
 #include "adcsattitudestate.h"
+#include "uscdl_endian.h"
 #include <string.h>
-#include <stdlib.h>
 
 void adcs_attitude_state_init(ADCSAttitudeState_t* p_data) {
     if (p_data == NULL) {
         return;
     }
-    memset(p_data->quaternion, 0.0, sizeof(p_data->quaternion));
-    memset(p_data->angularVelocity, 0.0, sizeof(p_data->angularVelocity));
+    quaternion_init(&p_data->quaternion);
+    vector_3_init(&p_data->angularVelocity);
     p_data->timestamp = 0;
-    p_data->attitudeDeterminationMode = 0;
+    p_data->attitudeDeterminationMode = ATTITUDE_DETERMINATION_MODE_NONE;
     p_data->attitudeValid = false;
 }
 
@@ -23,30 +23,49 @@ int adcs_attitude_state_serialize(const ADCSAttitudeState_t* p_data, uint8_t* bu
         return -1;
     }
 
-    // Ensure buffer is large enough
-    if (buffer_size < 34) {
-        return -1;
-    }
-
     size_t offset = 0;
     uint8_t* ptr = buffer;
-    size_t item_size = 0;
-    // Direct copy for little-endian or byte types
-    item_size = 4 * 4;
-    memcpy(ptr + offset, p_data->quaternion, item_size);
-    offset += item_size;
-    // Direct copy for little-endian or byte types
-    item_size = 4 * 3;
-    memcpy(ptr + offset, p_data->angularVelocity, item_size);
-    offset += item_size;
-    // Direct copy for little-endian or byte types
-    memcpy(ptr + offset, &p_data->timestamp, 4);
+
+    // quaternion
+    {
+        int written = quaternion_serialize(&p_data->quaternion, ptr + offset, buffer_size - offset);
+        if (written < 0) {
+            return -1;
+        }
+        offset += (size_t)written;
+    }
+
+    // angularVelocity
+    {
+        int written = vector_3_serialize(&p_data->angularVelocity, ptr + offset, buffer_size - offset);
+        if (written < 0) {
+            return -1;
+        }
+        offset += (size_t)written;
+    }
+
+    // timestamp
+    if (offset + 4 > buffer_size) {
+        return -1;
+    }
+    uscdl_put_u32_le(ptr + offset, p_data->timestamp);
     offset += 4;
-    // Direct copy for little-endian or byte types
-    memcpy(ptr + offset, &p_data->attitudeDeterminationMode, 1);
+
+    // attitudeDeterminationMode
+    if (offset + 1 > buffer_size) {
+        return -1;
+    }
+    if (!attitude_determination_mode_is_valid((uint8_t)p_data->attitudeDeterminationMode)) {
+        return -1;
+    }
+    uscdl_put_u8(ptr + offset, (uint8_t)p_data->attitudeDeterminationMode);
     offset += 1;
-    // Direct copy for little-endian or byte types
-    memcpy(ptr + offset, &p_data->attitudeValid, 1);
+
+    // attitudeValid
+    if (offset + 1 > buffer_size) {
+        return -1;
+    }
+    uscdl_put_bool(ptr + offset, p_data->attitudeValid);
     offset += 1;
 
     return (int)offset;
@@ -62,38 +81,50 @@ int adcs_attitude_state_deserialize(ADCSAttitudeState_t* p_data, const uint8_t*
 
     size_t offset = 0;
     const uint8_t* ptr = buffer;
-    size_t item_size = 0;
-    // Direct copy for little-endian or byte types
-    item_size = 4 * 4;
-    if (offset + item_size > buffer_size) {
-        return -1;
+
+    // quaternion
+    {
+        int consumed = quaternion_deserialize(&p_data->quaternion, ptr + offset, buffer_size - offset);
+        if (consumed < 0) {
+            return -1;
+        }
+        offset += (size_t)consumed;
     }
-    memcpy(p_data->quaternion, ptr + offset, item_size);
-    offset += item_size;
-    // Direct copy for little-endian or byte types
-    item_size = 4 * 3;
-    if (offset + item_size > buffer_size) {
-        return -1;
+
+    // angularVelocity
+    {
+        int consumed = vector_3_deserialize(&p_data->angularVelocity, ptr + offset, buffer_size - offset);
+        if (consumed < 0) {
+            return -1;
+        }
+        offset += (size_t)consumed;
     }
-    memcpy(p_data->angularVelocity, ptr + offset, item_size);
-    offset += item_size;
-    // Direct copy for little-endian or byte types
+
+    // timestamp
     if (offset + 4 > buffer_size) {
         return -1;
     }
-    memcpy(&p_data->timestamp, ptr + offset, 4);
+    p_data->timestamp = uscdl_get_u32_le(ptr + offset);
     offset += 4;
-    // Direct copy for little-endian or byte types
+
+    // attitudeDeterminationMode
     if (offset + 1 > buffer_size) {
         return -1;
     }
-    memcpy(&p_data->attitudeDeterminationMode, ptr + offset, 1);
+    {
+        uint8_t raw = uscdl_get_u8(ptr + offset);
+        if (!attitude_determination_mode_is_valid(raw)) {
+            return -1;
+        }
+        p_data->attitudeDeterminationMode = (AttitudeDeterminationMode_t)raw;
+    }
     offset += 1;
-    // Direct copy for little-endian or byte types
+
+    // attitudeValid
     if (offset + 1 > buffer_size) {
         return -1;
     }
-    memcpy(&p_data->attitudeValid, ptr + offset, 1);
+    p_data->attitudeValid = uscdl_get_bool(ptr + offset);
     offset += 1;
 
     return (int)offset;
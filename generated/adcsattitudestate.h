@@ -8,28 +8,66 @@
 
 #include <stdint.h>
   #include <stdbool.h>
+  #include <stddef.h>
+  #include "attitudedeterminationmode.h"
+  #include "quaternion.h"
+  #include "vector3.h"
 
     /**
     * Current attitude state of the spacecraft
     */
     typedef struct {
-    /* Quaternion representing the spacecraft attitude (quaternion) */
-    float quaternion[4];
+    /* Quaternion representing the spacecraft attitude */
+    Quaternion_t quaternion;
     /* Angular velocity vector of the spacecraft (rad/s) */
-    float angularVelocity[3];
+    Vector3_t angularVelocity;
     /* Timestamp of the attitude measurement (ms) */
     uint32_t timestamp;
-    /* Current mode of attitude determination (enum) */
-    uint8_t attitudeDeterminationMode;
+    /* Current mode of attitude determination */
+    AttitudeDeterminationMode_t attitudeDeterminationMode;
     /* Flag indicating if the attitude solution is valid */
     bool attitudeValid;
     } ADCSAttitudeState_t;
 
+    /* Serialized size bounds of ADCSAttitudeState in bytes */
+    #define ADCS_ATTITUDE_STATE_MIN_SIZE 34
+    #define ADCS_ATTITUDE_STATE_MAX_SIZE 34
+
+    /* Fingerprint of the wire layout of ADCSAttitudeState; peers built from a different layout have a different value */
+    #define ADCS_ATTITUDE_STATE_FINGERPRINT 0x93D23BF8u
+
     /**
     * Initialize a ADCSAttitudeState structure with default values
     * @param p_data Pointer to the structure to initialize
     */
     void adcs_attitude_state_init(ADCSAttitudeState_t* p_data);
 
+    /**
+    * Serialize a ADCSAttitudeState structure into a buffer
+    * @param p_data Pointer to the structure to serialize
+    * @param buffer Destination buffer
+    * @param buffer_size Size of the destination buffer in bytes
+    * @return Number of bytes written, or -1 on error
+    */
+    int adcs_attitude_state_serialize(const ADCSAttitudeState_t* p_data, uint8_t* buffer, size_t buffer_size);
+
+    /**
+    * Deserialize a ADCSAttitudeState structure from a buffer
+    * @param p_data Pointer to the structure to fill
+    * @param buffer Source buffer
+    * @param buffer_size Size of the source buffer in bytes
+    * @return Number of bytes read, or -1 on error
+    */
+    int adcs_attitude_state_deserialize(ADCSAttitudeState_t* p_data, const uint8_t* buffer, size_t buffer_size);
+
+    /**
+    * Check a peer's ADCSAttitudeState layout fingerprint against this build
+    * @param fingerprint The fingerprint reported by the peer
+    * @return true if both sides use the same wire layout
+    */
+    static inline bool adcs_attitude_state_check_fingerprint(uint32_t fingerprint) {
+        return fingerprint == ADCS_ATTITUDE_STATE_FINGERPRINT;
+    }
+
     #endif /* ADCSATTITUDESTATE_H */
     
\ No newline at end of file
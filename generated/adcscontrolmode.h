@@ -0,0 +1,47 @@
+/**
+* ADCSControlMode
+* Attitude control mode of the ADCS
+*/
+
+#ifndef ADCSCONTROLMODE_H
+#define ADCSCONTROLMODE_H
+
+#include <stdint.h>
+#include <stdbool.h>
+
+/**
+* Attitude control mode of the ADCS
+* Encoded on the wire as uint8
+*/
+typedef enum {
+    /* Actuators disabled */
+    ADCS_CONTROL_MODE_OFF = 0,
+    /* B-dot detumbling with magnetorquers */
+    ADCS_CONTROL_MODE_DETUMBLE = 1,
+    /* Point solar arrays at the sun */
+    ADCS_CONTROL_MODE_SUN_POINTING = 2,
+    /* Point payload at nadir */
+    ADCS_CONTROL_MODE_NADIR_POINTING = 3,
+    /* Track a commanded ground target */
+    ADCS_CONTROL_MODE_TARGET_TRACKING = 4,
+} ADCSControlMode_t;
+
+/**
+* Check whether a raw wire value is a legal ADCSControlMode
+* @param value The raw value to check
+* @return true if the value names a ADCSControlMode member
+*/
+static inline bool adcs_control_mode_is_valid(uint8_t value) {
+    switch (value) {
+    case 0:
+    case 1:
+    case 2:
+    case 3:
+    case 4:
+        return true;
+    default:
+        return false;
+    }
+}
+
+#endif /* ADCSCONTROLMODE_H */
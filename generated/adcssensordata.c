@@ -4,8 +4,8 @@
 */
 
 #include "adcssensordata.h"
+#include "uscdl_endian.h"
 #include <string.h>
-#include <stdlib.h>
 
 void adcs_sensor_data_init(ADCSSensorData_t* p_data) {
     if (p_data == NULL) {
@@ -13,7 +13,7 @@ void adcs_sensor_data_init(ADCSSensorData_t* p_data) {
     }
     memset(p_data->magnetometerReadings, 0, sizeof(p_data->magnetometerReadings));
     memset(p_data->sunSensorReadings, 0, sizeof(p_data->sunSensorReadings));
-    memset(p_data->gyroscopeReadings, 0.0, sizeof(p_data->gyroscopeReadings));
+    vector_3_init(&p_data->gyroscopeReadings);
     p_data->sensorTimestamp = 0;
     p_data->sensorsEnabled = 0;
 }
@@ -23,31 +23,48 @@ int adcs_sensor_data_serialize(const ADCSSensorData_t* p_data, uint8_t* buffer,
         return -1;
     }
 
-    // Ensure buffer is large enough
-    if (buffer_size < 35) {
-        return -1;
-    }
-
     size_t offset = 0;
     uint8_t* ptr = buffer;
-    size_t item_size = 0;
-    // Direct copy for little-endian or byte types
-    item_size = 2 * 3;
-    memcpy(ptr + offset, p_data->magnetometerReadings, item_size);
-    offset += item_size;
-    // Direct copy for little-endian or byte types
-    item_size = 2 * 6;
-    memcpy(ptr + offset, p_data->sunSensorReadings, item_size);
-    offset += item_size;
-    // Direct copy for little-endian or byte types
-    item_size = 4 * 3;
-    memcpy(ptr + offset, p_data->gyroscopeReadings, item_size);
-    offset += item_size;
-    // Direct copy for little-endian or byte types
-    memcpy(ptr + offset, &p_data->sensorTimestamp, 4);
+
+    // magnetometerReadings: 3 elements
+    for (size_t i = 0; i < 3; i++) {
+        if (offset + 2 > buffer_size) {
+            return -1;
+        }
+        uscdl_put_i16_le(ptr + offset, p_data->magnetometerReadings[i]);
+        offset += 2;
+    }
+
+    // sunSensorReadings: 6 elements
+    for (size_t i = 0; i < 6; i++) {
+        if (offset + 2 > buffer_size) {
+            return -1;
+        }
+        uscdl_put_u16_le(ptr + offset, p_data->sunSensorReadings[i]);
+        offset += 2;
+    }
+
+    // gyroscopeReadings
+    {
+        int written = vector_3_serialize(&p_data->gyroscopeReadings, ptr + offset, buffer_size - offset);
+        if (written < 0) {
+            return -1;
+        }
+        offset += (size_t)written;
+    }
+
+    // sensorTimestamp
+    if (offset + 4 > buffer_size) {
+        return -1;
+    }
+    uscdl_put_u32_le(ptr + offset, p_data->sensorTimestamp);
     offset += 4;
-    // Direct copy for little-endian or byte types
-    memcpy(ptr + offset, &p_data->sensorsEnabled, 1);
+
+    // sensorsEnabled
+    if (offset + 1 > buffer_size) {
+        return -1;
+    }
+    uscdl_put_u8(ptr + offset, p_data->sensorsEnabled);
     offset += 1;
 
     return (int)offset;
@@ -63,39 +80,46 @@ int adcs_sensor_data_deserialize(ADCSSensorData_t* p_data, const uint8_t* buffer
 
     size_t offset = 0;
     const uint8_t* ptr = buffer;
-    size_t item_size = 0;
-    // Direct copy for little-endian or byte types
-    item_size = 2 * 3;
-    if (offset + item_size > buffer_size) {
-        return -1;
+
+    // magnetometerReadings: 3 elements
+    for (size_t i = 0; i < 3; i++) {
+        if (offset + 2 > buffer_size) {
+            return -1;
+        }
+        p_data->magnetometerReadings[i] = uscdl_get_i16_le(ptr + offset);
+        offset += 2;
     }
-    memcpy(p_data->magnetometerReadings, ptr + offset, item_size);
-    offset += item_size;
-    // Direct copy for little-endian or byte types
-    item_size = 2 * 6;
-    if (offset + item_size > buffer_size) {
-        return -1;
+
+    // sunSensorReadings: 6 elements
+    for (size_t i = 0; i < 6; i++) {
+        if (offset + 2 > buffer_size) {
+            return -1;
+        }
+        p_data->sunSensorReadings[i] = uscdl_get_u16_le(ptr + offset);
+        offset += 2;
     }
-    memcpy(p_data->sunSensorReadings, ptr + offset, item_size);
-    offset += item_size;
-    // Direct copy for little-endian or byte types
-    item_size = 4 * 3;
-    if (offset + item_size > buffer_size) {
-        return -1;
+
+    // gyroscopeReadings
+    {
+        int consumed = vector_3_deserialize(&p_data->gyroscopeReadings, ptr + offset, buffer_size - offset);
+        if (consumed < 0) {
+            return -1;
+        }
+        offset += (size_t)consumed;
     }
-    memcpy(p_data->gyroscopeReadings, ptr + offset, item_size);
-    offset += item_size;
-    // Direct copy for little-endian or byte types
+
+    // sensorTimestamp
     if (offset + 4 > buffer_size) {
         return -1;
     }
-    memcpy(&p_data->sensorTimestamp, ptr + offset, 4);
+    p_data->sensorTimestamp = uscdl_get_u32_le(ptr + offset);
     offset += 4;
-    // Direct copy for little-endian or byte types
+
+    // sensorsEnabled
     if (offset + 1 > buffer_size) {
         return -1;
     }
-    memcpy(&p_data->sensorsEnabled, ptr + offset, 1);
+    p_data->sensorsEnabled = uscdl_get_u8(ptr + offset);
     offset += 1;
 
     return (int)offset;
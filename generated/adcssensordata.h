@@ -8,6 +8,8 @@
 
 #include <stdint.h>
   #include <stdbool.h>
+  #include <stddef.h>
+  #include "vector3.h"
 
     /**
     * Raw sensor data from ADCS sensors
@@ -18,18 +20,146 @@
     /* Raw sun sensor readings (counts) */
     uint16_t sunSensorReadings[6];
     /* Gyroscope readings (rad/s) */
-    float gyroscopeReadings[3];
+    Vector3_t gyroscopeReadings;
     /* Timestamp of the sensor readings (ms) */
     uint32_t sensorTimestamp;
     /* Bitmask of currently enabled sensors */
     uint8_t sensorsEnabled;
     } ADCSSensorData_t;
 
+    /* Serialized size bounds of ADCSSensorData in bytes */
+    #define ADCS_SENSOR_DATA_MIN_SIZE 35
+    #define ADCS_SENSOR_DATA_MAX_SIZE 35
+
+    /* Fingerprint of the wire layout of ADCSSensorData; peers built from a different layout have a different value */
+    #define ADCS_SENSOR_DATA_FINGERPRINT 0xCD3877E4u
+
+    /* Bits of ADCSSensorData.sensorsEnabled */
+    #define ADCS_SENSOR_DATA_SENSORS_ENABLED_MAGNETOMETER_SHIFT 0
+    #define ADCS_SENSOR_DATA_SENSORS_ENABLED_MAGNETOMETER_MASK 0x1u
+    #define ADCS_SENSOR_DATA_SENSORS_ENABLED_SUN_SENSORS_SHIFT 1
+    #define ADCS_SENSOR_DATA_SENSORS_ENABLED_SUN_SENSORS_MASK 0x2u
+    #define ADCS_SENSOR_DATA_SENSORS_ENABLED_GYROSCOPE_SHIFT 2
+    #define ADCS_SENSOR_DATA_SENSORS_ENABLED_GYROSCOPE_MASK 0x4u
+    #define ADCS_SENSOR_DATA_SENSORS_ENABLED_STAR_TRACKER_SHIFT 3
+    #define ADCS_SENSOR_DATA_SENSORS_ENABLED_STAR_TRACKER_MASK 0x8u
+
+    /**
+    * Get sensorsEnabled.magnetometer: Magnetometer enabled
+    * @param p_data Pointer to the structure to read
+    */
+    static inline bool adcs_sensor_data_get_sensors_enabled_magnetometer(const ADCSSensorData_t* p_data) {
+        return (p_data->sensorsEnabled & ADCS_SENSOR_DATA_SENSORS_ENABLED_MAGNETOMETER_MASK) != 0;
+    }
+
+    /**
+    * Set sensorsEnabled.magnetometer: Magnetometer enabled
+    * @param p_data Pointer to the structure to modify
+    * @param value The new value
+    */
+    static inline void adcs_sensor_data_set_sensors_enabled_magnetometer(ADCSSensorData_t* p_data, bool value) {
+        if (value) {
+            p_data->sensorsEnabled |= ADCS_SENSOR_DATA_SENSORS_ENABLED_MAGNETOMETER_MASK;
+        } else {
+            p_data->sensorsEnabled &= (uint8_t)~ADCS_SENSOR_DATA_SENSORS_ENABLED_MAGNETOMETER_MASK;
+        }
+    }
+
+    /**
+    * Get sensorsEnabled.sunSensors: Sun sensors enabled
+    * @param p_data Pointer to the structure to read
+    */
+    static inline bool adcs_sensor_data_get_sensors_enabled_sun_sensors(const ADCSSensorData_t* p_data) {
+        return (p_data->sensorsEnabled & ADCS_SENSOR_DATA_SENSORS_ENABLED_SUN_SENSORS_MASK) != 0;
+    }
+
+    /**
+    * Set sensorsEnabled.sunSensors: Sun sensors enabled
+    * @param p_data Pointer to the structure to modify
+    * @param value The new value
+    */
+    static inline void adcs_sensor_data_set_sensors_enabled_sun_sensors(ADCSSensorData_t* p_data, bool value) {
+        if (value) {
+            p_data->sensorsEnabled |= ADCS_SENSOR_DATA_SENSORS_ENABLED_SUN_SENSORS_MASK;
+        } else {
+            p_data->sensorsEnabled &= (uint8_t)~ADCS_SENSOR_DATA_SENSORS_ENABLED_SUN_SENSORS_MASK;
+        }
+    }
+
+    /**
+    * Get sensorsEnabled.gyroscope: Gyroscope enabled
+    * @param p_data Pointer to the structure to read
+    */
+    static inline bool adcs_sensor_data_get_sensors_enabled_gyroscope(const ADCSSensorData_t* p_data) {
+        return (p_data->sensorsEnabled & ADCS_SENSOR_DATA_SENSORS_ENABLED_GYROSCOPE_MASK) != 0;
+    }
+
+    /**
+    * Set sensorsEnabled.gyroscope: Gyroscope enabled
+    * @param p_data Pointer to the structure to modify
+    * @param value The new value
+    */
+    static inline void adcs_sensor_data_set_sensors_enabled_gyroscope(ADCSSensorData_t* p_data, bool value) {
+        if (value) {
+            p_data->sensorsEnabled |= ADCS_SENSOR_DATA_SENSORS_ENABLED_GYROSCOPE_MASK;
+        } else {
+            p_data->sensorsEnabled &= (uint8_t)~ADCS_SENSOR_DATA_SENSORS_ENABLED_GYROSCOPE_MASK;
+        }
+    }
+
+    /**
+    * Get sensorsEnabled.starTracker: Star tracker enabled
+    * @param p_data Pointer to the structure to read
+    */
+    static inline bool adcs_sensor_data_get_sensors_enabled_star_tracker(const ADCSSensorData_t* p_data) {
+        return (p_data->sensorsEnabled & ADCS_SENSOR_DATA_SENSORS_ENABLED_STAR_TRACKER_MASK) != 0;
+    }
+
+    /**
+    * Set sensorsEnabled.starTracker: Star tracker enabled
+    * @param p_data Pointer to the structure to modify
+    * @param value The new value
+    */
+    static inline void adcs_sensor_data_set_sensors_enabled_star_tracker(ADCSSensorData_t* p_data, bool value) {
+        if (value) {
+            p_data->sensorsEnabled |= ADCS_SENSOR_DATA_SENSORS_ENABLED_STAR_TRACKER_MASK;
+        } else {
+            p_data->sensorsEnabled &= (uint8_t)~ADCS_SENSOR_DATA_SENSORS_ENABLED_STAR_TRACKER_MASK;
+        }
+    }
+
     /**
     * Initialize a ADCSSensorData structure with default values
     * @param p_data Pointer to the structure to initialize
     */
     void adcs_sensor_data_init(ADCSSensorData_t* p_data);
 
+    /**
+    * Serialize a ADCSSensorData structure into a buffer
+    * @param p_data Pointer to the structure to serialize
+    * @param buffer Destination buffer
+    * @param buffer_size Size of the destination buffer in bytes
+    * @return Number of bytes written, or -1 on error
+    */
+    int adcs_sensor_data_serialize(const ADCSSensorData_t* p_data, uint8_t* buffer, size_t buffer_size);
+
+    /**
+    * Deserialize a ADCSSensorData structure from a buffer
+    * @param p_data Pointer to the structure to fill
+    * @param buffer Source buffer
+    * @param buffer_size Size of the source buffer in bytes
+    * @return Number of bytes read, or -1 on error
+    */
+    int adcs_sensor_data_deserialize(ADCSSensorData_t* p_data, const uint8_t* buffer, size_t buffer_size);
+
+    /**
+    * Check a peer's ADCSSensorData layout fingerprint against this build
+    * @param fingerprint The fingerprint reported by the peer
+    * @return true if both sides use the same wire layout
+    */
+    static inline bool adcs_sensor_data_check_fingerprint(uint32_t fingerprint) {
+        return fingerprint == ADCS_SENSOR_DATA_FINGERPRINT;
+    }
+
     #endif /* ADCSSENSORDATA_H */
     
\ No newline at end of file
@@ -0,0 +1,44 @@
+/**
+* AttitudeDeterminationMode
+* Source of the current attitude solution
+*/
+
+#ifndef ATTITUDEDETERMINATIONMODE_H
+#define ATTITUDEDETERMINATIONMODE_H
+
+#include <stdint.h>
+#include <stdbool.h>
+
+/**
+* Source of the current attitude solution
+* Encoded on the wire as uint8
+*/
+typedef enum {
+    /* No attitude solution available */
+    ATTITUDE_DETERMINATION_MODE_NONE = 0,
+    /* Coarse solution from sun sensors and magnetometer */
+    ATTITUDE_DETERMINATION_MODE_SUN_MAGNETOMETER = 1,
+    /* Propagated from gyroscope readings */
+    ATTITUDE_DETERMINATION_MODE_GYROSCOPE = 2,
+    /* Fine solution from the star tracker */
+    ATTITUDE_DETERMINATION_MODE_STAR_TRACKER = 3,
+} AttitudeDeterminationMode_t;
+
+/**
+* Check whether a raw wire value is a legal AttitudeDeterminationMode
+* @param value The raw value to check
+* @return true if the value names a AttitudeDeterminationMode member
+*/
+static inline bool attitude_determination_mode_is_valid(uint8_t value) {
+    switch (value) {
+    case 0:
+    case 1:
+    case 2:
+    case 3:
+        return true;
+    default:
+        return false;
+    }
+}
+
+#endif /* ATTITUDEDETERMINATIONMODE_H */
@@ -0,0 +1,99 @@
+/**
+* Quaternion
+* Attitude quaternion with the scalar part last
+*/
+
+#include "quaternion.h"
+#include "uscdl_endian.h"
+#include <string.h>
+
+void quaternion_init(Quaternion_t* p_data) {
+    if (p_data == NULL) {
+        return;
+    }
+    p_data->x = 0.0;
+    p_data->y = 0.0;
+    p_data->z = 0.0;
+    p_data->w = 0.0;
+}
+
+int quaternion_serialize(const Quaternion_t* p_data, uint8_t* buffer, size_t buffer_size) {
+    if (p_data == NULL || buffer == NULL) {
+        return -1;
+    }
+
+    size_t offset = 0;
+    uint8_t* ptr = buffer;
+
+    // x
+    if (offset + 4 > buffer_size) {
+        return -1;
+    }
+    uscdl_put_f32_le(ptr + offset, p_data->x);
+    offset += 4;
+
+    // y
+    if (offset + 4 > buffer_size) {
+        return -1;
+    }
+    uscdl_put_f32_le(ptr + offset, p_data->y);
+    offset += 4;
+
+    // z
+    if (offset + 4 > buffer_size) {
+        return -1;
+    }
+    uscdl_put_f32_le(ptr + offset, p_data->z);
+    offset += 4;
+
+    // w
+    if (offset + 4 > buffer_size) {
+        return -1;
+    }
+    uscdl_put_f32_le(ptr + offset, p_data->w);
+    offset += 4;
+
+    return (int)offset;
+}
+
+int quaternion_deserialize(Quaternion_t* p_data, const uint8_t* buffer, size_t buffer_size) {
+    if (p_data == NULL || buffer == NULL) {
+        return -1;
+    }
+
+    // Initialize the structure
+    quaternion_init(p_data);
+
+    size_t offset = 0;
+    const uint8_t* ptr = buffer;
+
+    // x
+    if (offset + 4 > buffer_size) {
+        return -1;
+    }
+    p_data->x = uscdl_get_f32_le(ptr + offset);
+    offset += 4;
+
+    // y
+    if (offset + 4 > buffer_size) {
+        return -1;
+    }
+    p_data->y = uscdl_get_f32_le(ptr + offset);
+    offset += 4;
+
+    // z
+    if (offset + 4 > buffer_size) {
+        return -1;
+    }
+    p_data->z = uscdl_get_f32_le(ptr + offset);
+    offset += 4;
+
+    // w
+    if (offset + 4 > buffer_size) {
+        return -1;
+    }
+    p_data->w = uscdl_get_f32_le(ptr + offset);
+    offset += 4;
+
+    return (int)offset;
+}
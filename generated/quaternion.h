@@ -0,0 +1,68 @@
+/**
+* Quaternion
+* Attitude quaternion with the scalar part last
+*/
+
+#ifndef QUATERNION_H
+#define QUATERNION_H
+
+#include <stdint.h>
+  #include <stdbool.h>
+  #include <stddef.h>
+
+    /**
+    * Attitude quaternion with the scalar part last
+    */
+    typedef struct {
+    /* First vector component */
+    float x;
+    /* Second vector component */
+    float y;
+    /* Third vector component */
+    float z;
+    /* Scalar component */
+    float w;
+    } Quaternion_t;
+
+    /* Serialized size bounds of Quaternion in bytes */
+    #define QUATERNION_MIN_SIZE 16
+    #define QUATERNION_MAX_SIZE 16
+
+    /* Fingerprint of the wire layout of Quaternion; peers built from a different layout have a different value */
+    #define QUATERNION_FINGERPRINT 0xE3D5898Du
+
+    /**
+    * Initialize a Quaternion structure with default values
+    * @param p_data Pointer to the structure to initialize
+    */
+    void quaternion_init(Quaternion_t* p_data);
+
+    /**
+    * Serialize a Quaternion structure into a buffer
+    * @param p_data Pointer to the structure to serialize
+    * @param buffer Destination buffer
+    * @param buffer_size Size of the destination buffer in bytes
+    * @return Number of bytes written, or -1 on error
+    */
+    int quaternion_serialize(const Quaternion_t* p_data, uint8_t* buffer, size_t buffer_size);
+
+    /**
+    * Deserialize a Quaternion structure from a buffer
+    * @param p_data Pointer to the structure to fill
+    * @param buffer Source buffer
+    * @param buffer_size Size of the source buffer in bytes
+    * @return Number of bytes read, or -1 on error
+    */
+    int quaternion_deserialize(Quaternion_t* p_data, const uint8_t* buffer, size_t buffer_size);
+
+    /**
+    * Check a peer's Quaternion layout fingerprint against this build
+    * @param fingerprint The fingerprint reported by the peer
+    * @return true if both sides use the same wire layout
+    */
+    static inline bool quaternion_check_fingerprint(uint32_t fingerprint) {
+        return fingerprint == QUATERNION_FINGERPRINT;
+    }
+
+    #endif /* QUATERNION_H */
+    
\ No newline at end of file
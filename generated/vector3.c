@@ -0,0 +1,84 @@
+/**
+* Vector3
+* Three-axis vector in the spacecraft body frame
+*/
+
+#include "vector3.h"
+#include "uscdl_endian.h"
+#include <string.h>
+
+void vector_3_init(Vector3_t* p_data) {
+    if (p_data == NULL) {
+        return;
+    }
+    p_data->x = 0.0;
+    p_data->y = 0.0;
+    p_data->z = 0.0;
+}
+
+int vector_3_serialize(const Vector3_t* p_data, uint8_t* buffer, size_t buffer_size) {
+    if (p_data == NULL || buffer == NULL) {
+        return -1;
+    }
+
+    size_t offset = 0;
+    uint8_t* ptr = buffer;
+
+    // x
+    if (offset + 4 > buffer_size) {
+        return -1;
+    }
+    uscdl_put_f32_le(ptr + offset, p_data->x);
+    offset += 4;
+
+    // y
+    if (offset + 4 > buffer_size) {
+        return -1;
+    }
+    uscdl_put_f32_le(ptr + offset, p_data->y);
+    offset += 4;
+
+    // z
+    if (offset + 4 > buffer_size) {
+        return -1;
+    }
+    uscdl_put_f32_le(ptr + offset, p_data->z);
+    offset += 4;
+
+    return (int)offset;
+}
+
+int vector_3_deserialize(Vector3_t* p_data, const uint8_t* buffer, size_t buffer_size) {
+    if (p_data == NULL || buffer == NULL) {
+        return -1;
+    }
+
+    // Initialize the structure
+    vector_3_init(p_data);
+
+    size_t offset = 0;
+    const uint8_t* ptr = buffer;
+
+    // x
+    if (offset + 4 > buffer_size) {
+        return -1;
+    }
+    p_data->x = uscdl_get_f32_le(ptr + offset);
+    offset += 4;
+
+    // y
+    if (offset + 4 > buffer_size) {
+        return -1;
+    }
+    p_data->y = uscdl_get_f32_le(ptr + offset);
+    offset += 4;
+
+    // z
+    if (offset + 4 > buffer_size) {
+        return -1;
+    }
+    p_data->z = uscdl_get_f32_le(ptr + offset);
+    offset += 4;
+
+    return (int)offset;
+}
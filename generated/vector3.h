@@ -0,0 +1,66 @@
+/**
+* Vector3
+* Three-axis vector in the spacecraft body frame
+*/
+
+#ifndef VECTOR3_H
+#define VECTOR3_H
+
+#include <stdint.h>
+  #include <stdbool.h>
+  #include <stddef.h>
+
+    /**
+    * Three-axis vector in the spacecraft body frame
+    */
+    typedef struct {
+    /* X axis component */
+    float x;
+    /* Y axis component */
+    float y;
+    /* Z axis component */
+    float z;
+    } Vector3_t;
+
+    /* Serialized size bounds of Vector3 in bytes */
+    #define VECTOR_3_MIN_SIZE 12
+    #define VECTOR_3_MAX_SIZE 12
+
+    /* Fingerprint of the wire layout of Vector3; peers built from a different layout have a different value */
+    #define VECTOR_3_FINGERPRINT 0x64A0E28Au
+
+    /**
+    * Initialize a Vector3 structure with default values
+    * @param p_data Pointer to the structure to initialize
+    */
+    void vector_3_init(Vector3_t* p_data);
+
+    /**
+    * Serialize a Vector3 structure into a buffer
+    * @param p_data Pointer to the structure to serialize
+    * @param buffer Destination buffer
+    * @param buffer_size Size of the destination buffer in bytes
+    * @return Number of bytes written, or -1 on error
+    */
+    int vector_3_serialize(const Vector3_t* p_data, uint8_t* buffer, size_t buffer_size);
+
+    /**
+    * Deserialize a Vector3 structure from a buffer
+    * @param p_data Pointer to the structure to fill
+    * @param buffer Source buffer
+    * @param buffer_size Size of the source buffer in bytes
+    * @return Number of bytes read, or -1 on error
+    */
+    int vector_3_deserialize(Vector3_t* p_data, const uint8_t* buffer, size_t buffer_size);
+
+    /**
+    * Check a peer's Vector3 layout fingerprint against this build
+    * @param fingerprint The fingerprint reported by the peer
+    * @return true if both sides use the same wire layout
+    */
+    static inline bool vector_3_check_fingerprint(uint32_t fingerprint) {
+        return fingerprint == VECTOR_3_FINGERPRINT;
+    }
+
+    #endif /* VECTOR3_H */
+    
\ No newline at end of file
@@ -2,15 +2,17 @@ module github.com/sammyjroberts/uscdl
 
 go 1.24.1
 
-require github.com/labstack/echo/v4 v4.13.3
+require (
+	github.com/iancoleman/strcase v0.3.0
+	github.com/labstack/echo/v4 v4.13.3
+	github.com/santhosh-tekuri/jsonschema/v5 v5.3.1
+)
 
 require (
 	github.com/a-h/templ v0.3.857 // indirect
-	github.com/iancoleman/strcase v0.3.0 // indirect
 	github.com/labstack/gommon v0.4.2 // indirect
 	github.com/mattn/go-colorable v0.1.13 // indirect
 	github.com/mattn/go-isatty v0.0.20 // indirect
-	github.com/santhosh-tekuri/jsonschema/v5 v5.3.1 // indirect
 	github.com/valyala/bytebufferpool v1.0.0 // indirect
 	github.com/valyala/fasttemplate v1.2.2 // indirect
 	golang.org/x/crypto v0.36.0 // indirect
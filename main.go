@@ -1,146 +1,131 @@
 package main
 
 import (
-	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
-	"io/ioutil"
-	"log"
 	"os"
-	"path/filepath"
 	"strings"
-
-	"github.com/sammyjroberts/uscdl/templates"
-	"github.com/santhosh-tekuri/jsonschema/v5"
 )
 
-// Item represents a property within a container
-type Item struct {
-	Name        string `json:"name"`
-	Type        string `json:"type"`
-	Description string `json:"description"`
-	ByteOrder   string `json:"byteOrder"`
-	Units       string `json:"units"`
-	IsArray     bool   `json:"isArray"`
-	Length      int    `json:"length"`
-}
+// errUsage marks errors caused by invalid command-line arguments
+var errUsage = errors.New("invalid arguments")
 
-// Container represents a struct that contains multiple items
-type Container struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Items       []Item `json:"items"`
+// command is a uscdl subcommand
+type command struct {
+	run     func(args []string) error
+	summary string
 }
 
-// Config represents the entire configuration
-type Config struct {
-	Containers []Container `json:"containers"`
+// commands lists the subcommands by name
+var commands = map[string]command{
+	"generate": {runGenerate, "generate code for the selected backends"},
+	"validate": {runValidate, "validate a config against the schema and layout rules"},
+	"decode":   {runDecode, "decode a binary frame into JSON or a table"},
+	"encode":   {runEncode, "encode JSON values into a binary frame"},
+	"docs":     {runDocs, "write Markdown documentation of a config"},
+	"compat":   {runCompat, "report wire layout changes between two versions of a config"},
 }
 
-func main() {
-	if len(os.Args) < 2 {
-		log.Fatal("Usage: go run main.go <config.json> [schema.json]")
-	}
-
-	configFile := os.Args[1]
-	outputDir := "generated"
-	schemaFile := "schema.json"
+// commandOrder is the order subcommands are listed in the usage text
+var commandOrder = []string{"generate", "validate", "decode", "encode", "docs", "compat"}
 
-	if len(os.Args) >= 3 {
-		schemaFile = os.Args[2]
-	}
+func main() {
+	os.Exit(run(os.Args[1:]))
+}
 
-	// Ensure output directory exists
-	if err := os.MkdirAll(outputDir, 0755); err != nil {
-		log.Fatalf("Failed to create output directory: %v", err)
+// run dispatches to a subcommand and returns the process exit code: 0 on
+// success, 1 on failure, 2 on invalid arguments and 3 when compat finds
+// breaking changes
+func run(args []string) int {
+	if len(args) == 0 {
+		usage()
+		return 2
 	}
 
-	// Read and parse config file
-	configData, err := ioutil.ReadFile(configFile)
-	if err != nil {
-		log.Fatalf("Failed to read config file: %v", err)
+	name := args[0]
+	if name == "-h" || name == "-help" || name == "--help" || name == "help" {
+		usage()
+		return 0
 	}
 
-	// Validate against JSON Schema
-	compiler := jsonschema.NewCompiler()
-	schema, err := compiler.Compile(schemaFile)
-	if err != nil {
-		log.Fatalf("Failed to compile schema: %v", err)
+	// uscdl <config.json> [schema.json] is the original generate invocation
+	cmd, ok := commands[name]
+	if !ok && strings.HasSuffix(name, ".json") {
+		legacy := []string{name}
+		if len(args) >= 2 {
+			legacy = []string{"-schema", args[1], name}
+		}
+		return exitCode("generate", runGenerate(legacy))
 	}
-
-	var jsonData interface{}
-	if err := json.Unmarshal(configData, &jsonData); err != nil {
-		log.Fatalf("Failed to parse JSON: %v", err)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "uscdl: unknown command %q\n\n", name)
+		usage()
+		return 2
 	}
+	return exitCode(name, cmd.run(args[1:]))
+}
 
-	if err := schema.Validate(jsonData); err != nil {
-		log.Fatalf("Validation error: %v", err)
+// exitCode reports a subcommand error and maps it to an exit code
+func exitCode(name string, err error) int {
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+		return 0
+	case errors.Is(err, errUsage):
+		fmt.Fprintf(os.Stderr, "%s: %v\n", name, err)
+		return 2
+	case errors.Is(err, errBreaking):
+		fmt.Fprintf(os.Stderr, "%s: %v\n", name, err)
+		return 3
+	default:
+		fmt.Fprintf(os.Stderr, "%s: %v\n", name, err)
+		return 1
 	}
+}
 
-	log.Println("Configuration is valid!")
-
-	// Parse JSON into Config struct
-	var config Config
-	if err := json.Unmarshal(configData, &config); err != nil {
-		log.Fatalf("Failed to parse JSON: %v", err)
+// usage prints the list of subcommands
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: uscdl <command> [flags] [arguments]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Commands:")
+	for _, name := range commandOrder {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", name, commands[name].summary)
 	}
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Run uscdl <command> -h for the flags of a command.")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Exit status: 0 on success, 1 on failure, 2 on invalid arguments and 3 when")
+	fmt.Fprintln(os.Stderr, "compat finds breaking changes.")
+}
 
-	// Generate code for each container
-	for _, container := range config.Containers {
-		// Convert to template Container type
-		tmplContainer := templates.Container{
-			Name:        container.Name,
-			Description: container.Description,
-			Items:       make([]templates.Item, len(container.Items)),
-		}
-
-		for i, item := range container.Items {
-			tmplContainer.Items[i] = templates.Item{
-				Name:        item.Name,
-				Type:        item.Type,
-				Description: item.Description,
-				ByteOrder:   item.ByteOrder,
-				Units:       item.Units,
-				IsArray:     item.IsArray,
-				Length:      item.Length,
-			}
-		}
-
-		// Generate C header file
-		headerFile, err := os.Create(filepath.Join(outputDir, fmt.Sprintf("%s.h", strings.ToLower(container.Name))))
-		if err != nil {
-			log.Fatalf("Failed to create header file: %v", err)
-		}
-		defer headerFile.Close()
-
-		if err := templates.CHeaderTemplate.Execute(headerFile, tmplContainer); err != nil {
-			log.Fatalf("Failed to render header template: %v", err)
-		}
-		fmt.Printf("Generated C header file: %s\n", headerFile.Name())
-
-		// Generate C source file
-		sourceFile, err := os.Create(filepath.Join(outputDir, fmt.Sprintf("%s.c", strings.ToLower(container.Name))))
-		if err != nil {
-			log.Fatalf("Failed to create source file: %v", err)
-		}
-		defer sourceFile.Close()
-
-		if err := templates.CSourceTemplate.Execute(sourceFile, tmplContainer); err != nil {
-			log.Fatalf("Failed to render source template: %v", err)
+// newFlagSet returns a flag set for a subcommand with a usage line
+func newFlagSet(name, arguments string, notes ...string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: uscdl %s [flags] %s\n", name, arguments)
+		for _, note := range notes {
+			fmt.Fprintln(flags.Output(), note)
 		}
-		fmt.Printf("Generated C source file: %s\n", sourceFile.Name())
+		flags.PrintDefaults()
+	}
+	return flags
+}
 
-		// Generate TypeScript file
-		tsFile, err := os.Create(filepath.Join(outputDir, fmt.Sprintf("%s.ts", container.Name)))
-		if err != nil {
-			log.Fatalf("Failed to create TypeScript file: %v", err)
+// parseFlags parses subcommand flags and checks the number of positional
+// arguments; a negative maxArgs allows any number above minArgs
+func parseFlags(flags *flag.FlagSet, args []string, minArgs, maxArgs int) error {
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
 		}
-		defer tsFile.Close()
-
-		if err := templates.TypeScriptTemplate.Execute(tsFile, tmplContainer); err != nil {
-			log.Fatalf("Failed to render TypeScript template: %v", err)
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	if flags.NArg() < minArgs || maxArgs >= 0 && flags.NArg() > maxArgs {
+		flags.Usage()
+		if maxArgs < 0 {
+			return fmt.Errorf("%w: expected at least %d arguments, got %d", errUsage, minArgs, flags.NArg())
 		}
-		fmt.Printf("Generated TypeScript file: %s\n", tsFile.Name())
+		return fmt.Errorf("%w: expected %d to %d arguments, got %d", errUsage, minArgs, maxArgs, flags.NArg())
 	}
-
-	fmt.Println("Code generation completed successfully!")
+	return nil
 }
@@ -0,0 +1,265 @@
+package model
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// cReservedWords lists the C keywords and standard type names that cannot
+// name a generated struct, field or enum
+var cReservedWords = map[string]bool{
+	"auto": true, "break": true, "case": true, "char": true, "const": true,
+	"continue": true, "default": true, "do": true, "double": true, "else": true,
+	"enum": true, "extern": true, "float": true, "for": true, "goto": true,
+	"if": true, "inline": true, "int": true, "long": true, "register": true,
+	"restrict": true, "return": true, "short": true, "signed": true,
+	"sizeof": true, "static": true, "struct": true, "switch": true,
+	"typedef": true, "union": true, "unsigned": true, "void": true,
+	"volatile": true, "while": true, "bool": true, "true": true, "false": true,
+	"_Alignas": true, "_Alignof": true, "_Atomic": true, "_Bool": true,
+	"_Complex": true, "_Generic": true, "_Imaginary": true, "_Noreturn": true,
+	"_Static_assert": true, "_Thread_local": true,
+	"int8_t": true, "int16_t": true, "int32_t": true, "int64_t": true,
+	"uint8_t": true, "uint16_t": true, "uint32_t": true, "uint64_t": true,
+	"size_t": true, "NULL": true,
+}
+
+// tsReservedWords lists the TypeScript reserved words and built-in type names
+// that cannot name a generated interface, type or variable
+var tsReservedWords = map[string]bool{
+	"break": true, "case": true, "catch": true, "class": true, "const": true,
+	"continue": true, "debugger": true, "default": true, "delete": true,
+	"do": true, "else": true, "enum": true, "export": true, "extends": true,
+	"false": true, "finally": true, "for": true, "function": true, "if": true,
+	"import": true, "in": true, "instanceof": true, "new": true, "null": true,
+	"return": true, "super": true, "switch": true, "this": true, "throw": true,
+	"true": true, "try": true, "typeof": true, "var": true, "void": true,
+	"while": true, "with": true, "implements": true, "interface": true,
+	"let": true, "package": true, "private": true, "protected": true,
+	"public": true, "static": true, "yield": true, "any": true,
+	"boolean": true, "number": true, "string": true, "symbol": true,
+	"never": true, "unknown": true, "object": true, "undefined": true,
+	"Array": true, "DataView": true, "Error": true, "Object": true,
+	"RangeError": true,
+}
+
+// reservedIn returns the languages in which a name is reserved
+func reservedIn(name string) []string {
+	var languages []string
+	if cReservedWords[name] {
+		languages = append(languages, "C")
+	}
+	if tsReservedWords[name] {
+		languages = append(languages, "TypeScript")
+	}
+	return languages
+}
+
+// checkName reports a name that is reserved in a target language
+func (l *problemList) checkName(path, kind, name string) {
+	if languages := reservedIn(name); languages != nil {
+		l.add("reserved-word", path, "%s name %q is a reserved word in %s", kind, name, strings.Join(languages, " and "))
+	}
+}
+
+// checkSemantics reports duplicate names, reserved names and item settings
+// that have no effect
+func (c *Config) checkSemantics(problems *problemList) {
+	enums := make(map[string]int)
+	for e, enum := range c.Enums {
+		path := fmt.Sprintf("/enums/%d", e)
+		if first, ok := enums[enum.Name]; ok {
+			problems.add("duplicate-enum", path+"/name", "duplicate enum name %q, first defined at /enums/%d", enum.Name, first)
+		} else {
+			enums[enum.Name] = e
+		}
+		problems.checkName(path+"/name", "enum", enum.Name)
+
+		values := make(map[string]int)
+		numbers := make(map[int]int)
+		min, max, ranged := integerRange(enum.Type)
+		for v, value := range enum.Values {
+			valuePath := fmt.Sprintf("%s/values/%d", path, v)
+			if first, ok := values[value.Name]; ok {
+				problems.add("duplicate-enum-value", valuePath+"/name", "duplicate value name %q in enum %s, first defined at %s/values/%d", value.Name, enum.Name, path, first)
+			} else {
+				values[value.Name] = v
+			}
+			if first, ok := numbers[value.Value]; ok {
+				problems.add("duplicate-enum-value-number", valuePath+"/value", "value %s = %d in enum %s repeats the value of %s at %s/values/%d", value.Name, value.Value, enum.Name, enum.Values[first].Name, path, first)
+			} else {
+				numbers[value.Value] = v
+			}
+			if ranged && (int64(value.Value) < min || int64(value.Value) > max) {
+				problems.add("enum-value-range", valuePath+"/value", "value %s = %d does not fit the %s type of enum %s, which holds %d to %d", value.Name, value.Value, enum.Type, enum.Name, min, max)
+			}
+		}
+	}
+
+	containers := make(map[string]int)
+	for ci, container := range c.Containers {
+		path := fmt.Sprintf("/containers/%d", ci)
+		if first, ok := containers[container.Name]; ok {
+			problems.add("duplicate-container", path+"/name", "duplicate container name %q, first defined at /containers/%d", container.Name, first)
+		} else {
+			containers[container.Name] = ci
+		}
+		if e, ok := enums[container.Name]; ok {
+			problems.add("name-clash", path+"/name", "container name %q is also the name of the enum at /enums/%d", container.Name, e)
+		}
+		problems.checkName(path+"/name", "container", container.Name)
+
+		items := make(map[string]int)
+		for i := range container.Items {
+			item := &container.Items[i]
+			itemPath := fmt.Sprintf("%s/items/%d", path, i)
+			if first, ok := items[item.Name]; ok {
+				problems.add("duplicate-item", itemPath+"/name", "duplicate item name %q in container %s, first defined at %s/items/%d", item.Name, container.Name, path, first)
+			} else {
+				items[item.Name] = i
+			}
+			problems.checkName(itemPath+"/name", "item", item.Name)
+			c.checkItem(problems, itemPath, item)
+		}
+	}
+}
+
+// checkItem reports item settings that contradict each other or have no
+// effect on the encoding
+func (c *Config) checkItem(problems *problemList, path string, item *Item) {
+	if item.Length != 0 && !item.IsArray {
+		problems.add("length-without-array", path+"/length", "length is set but isArray is false")
+	}
+	if item.MaxLength != 0 && !item.IsArray && item.Type != "string" {
+		problems.add("max-length-unused", path+"/maxLength", "maxLength is set but the item is neither an array nor a string")
+	}
+
+	if item.ByteOrder == "big" && c.singleByte(item) {
+		problems.add("byte-order-no-effect", path+"/byteOrder", "byteOrder \"big\" has no effect on %s, which is encoded in single bytes", c.describeWireType(item))
+	}
+
+	bits := make(map[string]int)
+	// owner records which bit claims each position of the base type
+	owner := make(map[int]int)
+	size := 8 * PrimitiveSize(item.BaseType)
+	for b, bit := range item.Bits {
+		bitPath := fmt.Sprintf("%s/bits/%d", path, b)
+		if first, ok := bits[bit.Name]; ok {
+			problems.add("duplicate-bit", bitPath+"/name", "duplicate bit name %q, first defined at %s/bits/%d", bit.Name, path, first)
+		} else {
+			bits[bit.Name] = b
+		}
+
+		if item.Type != "bitfield" || size == 0 {
+			continue
+		}
+		if bit.Offset < 0 || bit.Width < 1 || bit.Offset+bit.Width > size {
+			problems.add("bit-range", bitPath, "bit %s at offset %d with width %d does not fit the %d bits of %s", bit.Name, bit.Offset, bit.Width, size, item.BaseType)
+			continue
+		}
+		for position := bit.Offset; position < bit.Offset+bit.Width; position++ {
+			if first, ok := owner[position]; ok {
+				problems.add("bit-overlap", bitPath, "bit %s overlaps bit %s at bit position %d, first defined at %s/bits/%d", bit.Name, item.Bits[first].Name, position, path, first)
+				break
+			}
+		}
+		for position := bit.Offset; position < bit.Offset+bit.Width; position++ {
+			if _, ok := owner[position]; !ok {
+				owner[position] = b
+			}
+		}
+	}
+}
+
+// integerRange returns the smallest and largest values of an integer type of
+// at most 32 bits
+func integerRange(itemType string) (int64, int64, bool) {
+	switch itemType {
+	case "uint8":
+		return 0, math.MaxUint8, true
+	case "uint16":
+		return 0, math.MaxUint16, true
+	case "uint32":
+		return 0, math.MaxUint32, true
+	case "int8":
+		return math.MinInt8, math.MaxInt8, true
+	case "int16":
+		return math.MinInt16, math.MaxInt16, true
+	case "int32":
+		return math.MinInt32, math.MaxInt32, true
+	}
+	return 0, 0, false
+}
+
+// singleByte reports whether every value an item puts on the wire, including
+// any length prefix, is a single byte, so that its byte order cannot matter
+func (c *Config) singleByte(item *Item) bool {
+	prefixed := item.IsVariableArray() && item.LengthField == "" ||
+		item.Type == "string" && item.Encoding == "prefixed"
+	if prefixed && PrimitiveSize(item.LengthType) > 1 {
+		return false
+	}
+
+	switch item.Type {
+	case "container":
+		// Nested containers use the byte order of their own items
+		return false
+	case "string":
+		return true
+	case "enum":
+		if enum := c.Enum(item.Enum); enum != nil {
+			return PrimitiveSize(enum.Type) == 1
+		}
+		return false
+	case "bitfield":
+		return PrimitiveSize(item.BaseType) == 1
+	default:
+		return PrimitiveSize(item.Type) == 1
+	}
+}
+
+// describeWireType names the type an item is encoded as for messages
+func (c *Config) describeWireType(item *Item) string {
+	switch item.Type {
+	case "enum":
+		if enum := c.Enum(item.Enum); enum != nil {
+			return fmt.Sprintf("enum %s (%s)", enum.Name, enum.Type)
+		}
+	case "bitfield":
+		return fmt.Sprintf("bitfield (%s)", item.BaseType)
+	}
+	return item.Type
+}
+
+// CheckDuplicates reports enums and containers defined in more than one of a
+// set of configs, whose generated files would overwrite each other
+func CheckDuplicates(configs []*Config) []Problem {
+	type definition struct {
+		config *Config
+		path   string
+	}
+	var problems problemList
+	defined := make(map[string]definition)
+	check := func(config *Config, kind, name, path string) {
+		first, ok := defined[name]
+		if !ok {
+			defined[name] = definition{config, path}
+			return
+		}
+		if first.config != config {
+			problems.add("duplicate-definition", path, "%s name %q is also defined at %s:%s", kind, name, first.config.file, first.path)
+		}
+	}
+	for _, config := range configs {
+		start := len(problems)
+		for e, enum := range config.Enums {
+			check(config, "enum", enum.Name, fmt.Sprintf("/enums/%d/name", e))
+		}
+		for c, container := range config.Containers {
+			check(config, "container", container.Name, fmt.Sprintf("/containers/%d/name", c))
+		}
+		locate(problems[start:], config.file, config.source)
+	}
+	return problems
+}
@@ -0,0 +1,284 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// Severities of problems
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// Rule describes one kind of problem
+type Rule struct {
+	ID          string
+	Severity    string
+	Description string
+}
+
+// Rules lists every rule the checker reports, in a stable order
+var Rules = []Rule{
+	{"json-syntax", SeverityError, "The config is not well-formed JSON"},
+	{"schema", SeverityError, "The config does not match the JSON Schema"},
+	{"unknown-enum", SeverityError, "An item references an enum that is not defined"},
+	{"unknown-container", SeverityError, "An item references a container that is not defined"},
+	{"length-field", SeverityError, "A lengthField does not name a preceding integer item"},
+	{"container-cycle", SeverityError, "A container nests itself"},
+	{"duplicate-enum", SeverityError, "Two enums have the same name"},
+	{"duplicate-enum-value", SeverityError, "Two values of an enum have the same name"},
+	{"duplicate-enum-value-number", SeverityError, "Two values of an enum have the same number"},
+	{"enum-value-range", SeverityError, "An enum value does not fit the enum's type"},
+	{"duplicate-container", SeverityError, "Two containers have the same name"},
+	{"duplicate-item", SeverityError, "Two items of a container have the same name"},
+	{"duplicate-bit", SeverityError, "Two bits of a bitfield have the same name"},
+	{"bit-range", SeverityError, "A bit does not fit the base type of its bitfield"},
+	{"bit-overlap", SeverityError, "Two bits of a bitfield share a bit position"},
+	{"duplicate-definition", SeverityError, "An enum or container is defined in more than one config"},
+	{"name-clash", SeverityError, "A container has the same name as an enum"},
+	{"reserved-word", SeverityError, "A name is a reserved word in a generated language"},
+	{"length-without-array", SeverityError, "length is set on an item that is not an array"},
+	{"max-length-unused", SeverityWarning, "maxLength is set on an item that is neither an array nor a string"},
+	{"byte-order-no-effect", SeverityWarning, "byteOrder is set on an item encoded in single bytes"},
+}
+
+// ruleSeverity returns the severity of a rule
+func ruleSeverity(id string) string {
+	for _, rule := range Rules {
+		if rule.ID == id {
+			return rule.Severity
+		}
+	}
+	return SeverityError
+}
+
+// Problem is a diagnostic about a config
+type Problem struct {
+	// File is the config file, when known
+	File string `json:"file,omitempty"`
+	// Line and Column give the 1-based position of the offending value when
+	// the config source is known
+	Line   int `json:"line,omitempty"`
+	Column int `json:"column,omitempty"`
+	// Path is the JSON Pointer of the offending value
+	Path string `json:"path"`
+	// Severity is SeverityError or SeverityWarning
+	Severity string `json:"severity"`
+	// Rule is the ID of the rule that found the problem
+	Rule string `json:"rule"`
+	// Message describes the problem
+	Message string `json:"message"`
+}
+
+// String formats the problem as file:line:column: severity[rule]: message
+func (p Problem) String() string {
+	var location string
+	switch {
+	case p.File != "" && p.Line > 0:
+		location = fmt.Sprintf("%s:%d:%d", p.File, p.Line, p.Column)
+	case p.File != "":
+		location = p.File
+	case p.Line > 0:
+		location = fmt.Sprintf("%d:%d", p.Line, p.Column)
+	}
+	text := fmt.Sprintf("%s[%s]: %s", p.Severity, p.Rule, p.Message)
+	if p.Path != "" {
+		text += " (at " + p.Path + ")"
+	}
+	if location == "" {
+		return text
+	}
+	return location + ": " + text
+}
+
+// ValidationError reports every problem found in one or more configs when at
+// least one of them is an error
+type ValidationError struct {
+	Problems []Problem
+}
+
+// Error lists the problems one per line
+func (e *ValidationError) Error() string {
+	if len(e.Problems) == 1 {
+		return e.Problems[0].String()
+	}
+	lines := make([]string, len(e.Problems))
+	for i, p := range e.Problems {
+		lines[i] = "  " + p.String()
+	}
+	return fmt.Sprintf("%d problems:\n%s", len(e.Problems), strings.Join(lines, "\n"))
+}
+
+// HasErrors reports whether any problem has error severity
+func HasErrors(problems []Problem) bool {
+	for _, p := range problems {
+		if p.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// problemList collects problems while checking a config
+type problemList []Problem
+
+// add records a problem found by a rule at a JSON Pointer
+func (l *problemList) add(rule, path, format string, args ...interface{}) {
+	*l = append(*l, Problem{
+		Path:     path,
+		Severity: ruleSeverity(rule),
+		Rule:     rule,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// err returns the problems as a *ValidationError when any is an error
+func (l problemList) err() error {
+	if !HasErrors(l) {
+		return nil
+	}
+	return &ValidationError{Problems: l}
+}
+
+// addSchemaErrors records the leaf errors of a JSON Schema validation error
+func (l *problemList) addSchemaErrors(err *jsonschema.ValidationError) {
+	if len(err.Causes) == 0 {
+		l.add("schema", err.InstanceLocation, "%s", err.Message)
+		return
+	}
+	for _, cause := range err.Causes {
+		l.addSchemaErrors(cause)
+	}
+}
+
+// locate fills in the file, line and column of each problem from the config
+// source
+func locate(problems []Problem, file string, data []byte) {
+	offsets := valueOffsets(data)
+	for i := range problems {
+		problems[i].File = file
+		if data == nil {
+			continue
+		}
+		// Fall back to the closest enclosing value for locations that do
+		// not exist, such as missing required properties
+		path := problems[i].Path
+		offset, ok := offsets[path]
+		for !ok && path != "" {
+			path = path[:strings.LastIndex(path, "/")]
+			offset, ok = offsets[path]
+		}
+		if ok {
+			problems[i].Line, problems[i].Column = lineColumn(data, offset)
+		}
+	}
+}
+
+// syntaxProblem converts a JSON parse error into a problem, locating it when
+// the error carries an offset
+func syntaxProblem(err error, data []byte) Problem {
+	p := Problem{Severity: SeverityError, Rule: "json-syntax", Message: err.Error()}
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		p.Line, p.Column = lineColumn(data, int(syntaxErr.Offset))
+	case errors.As(err, &typeErr):
+		p.Line, p.Column = lineColumn(data, int(typeErr.Offset))
+	}
+	return p
+}
+
+// valueOffsets maps the JSON Pointer of every value in a JSON document to the
+// byte offset where the value starts. It returns what it found so far when
+// the document is malformed.
+func valueOffsets(data []byte) map[string]int {
+	offsets := make(map[string]int)
+	if data == nil {
+		return offsets
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	_ = walkValue(decoder, data, "", offsets)
+	return offsets
+}
+
+// walkValue records the offset of the next value and of everything nested in
+// it
+func walkValue(decoder *json.Decoder, data []byte, path string, offsets map[string]int) error {
+	// The decoder's offset is just past the previous token, before any
+	// separators and whitespace
+	start := int(decoder.InputOffset())
+	for start < len(data) && strings.IndexByte(" \t\r\n,:", data[start]) >= 0 {
+		start++
+	}
+	offsets[path] = start
+
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	switch token {
+	case json.Delim('{'):
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return err
+			}
+			name, _ := key.(string)
+			if err := walkValue(decoder, data, path+"/"+escapePointer(name), offsets); err != nil {
+				return err
+			}
+		}
+		_, err = decoder.Token()
+	case json.Delim('['):
+		for i := 0; decoder.More(); i++ {
+			if err := walkValue(decoder, data, path+"/"+strconv.Itoa(i), offsets); err != nil {
+				return err
+			}
+		}
+		_, err = decoder.Token()
+	}
+	return err
+}
+
+// escapePointer escapes a key for use as a JSON Pointer reference token
+func escapePointer(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
+
+// lineColumn converts a byte offset into a 1-based line and column, counting
+// columns in characters
+func lineColumn(data []byte, offset int) (int, int) {
+	if offset > len(data) {
+		offset = len(data)
+	}
+	line := 1 + bytes.Count(data[:offset], []byte("\n"))
+	lineStart := bytes.LastIndexByte(data[:offset], '\n') + 1
+	return line, 1 + utf8.RuneCount(data[lineStart:offset])
+}
+
+// SortProblems orders problems by file, then position, then path
+func SortProblems(problems []Problem) {
+	sort.SliceStable(problems, func(i, j int) bool {
+		a, b := problems[i], problems[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		if a.Column != b.Column {
+			return a.Column < b.Column
+		}
+		return a.Path < b.Path
+	})
+}
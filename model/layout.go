@@ -0,0 +1,94 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"strings"
+)
+
+// Layout returns a canonical description of the wire layout of a validated
+// container: the order, types, sizes and byte order of its items, with nested
+// containers expanded. Names and descriptions are left out, so renaming or
+// documenting an item keeps the layout.
+func (c *Container) Layout() string {
+	var b strings.Builder
+	writeLayout(&b, c)
+	return b.String()
+}
+
+// Fingerprint returns a 32-bit hash of a container's Layout, which peers can
+// exchange to detect that they were built from different layouts
+func (c *Container) Fingerprint() uint32 {
+	sum := sha256.Sum256([]byte(c.Layout()))
+	return binary.BigEndian.Uint32(sum[:4])
+}
+
+// writeLayout appends the layout of a container
+func writeLayout(b *strings.Builder, container *Container) {
+	b.WriteByte('{')
+	for i := range container.Items {
+		if i > 0 {
+			b.WriteByte(';')
+		}
+		writeItemLayout(b, container, &container.Items[i])
+	}
+	b.WriteByte('}')
+}
+
+// writeItemLayout appends the layout of an item
+func writeItemLayout(b *strings.Builder, container *Container, item *Item) {
+	switch item.Type {
+	case "container":
+		if item.ContainerType != nil {
+			writeLayout(b, item.ContainerType)
+		}
+	case "string":
+		if item.Encoding == "prefixed" {
+			fmt.Fprintf(b, "string(%s,%d)", orderedType(item.LengthType, item.ByteOrder), item.MaxLength)
+		} else {
+			fmt.Fprintf(b, "string(%d)", item.MaxLength)
+		}
+	case "enum":
+		if item.EnumType != nil {
+			fmt.Fprintf(b, "enum(%s)", orderedType(item.EnumType.Type, item.ByteOrder))
+		}
+	case "bitfield":
+		fmt.Fprintf(b, "bitfield(%s", orderedType(item.BaseType, item.ByteOrder))
+		for _, bit := range item.Bits {
+			fmt.Fprintf(b, ",%d:%d", bit.Offset, bit.Width)
+		}
+		b.WriteByte(')')
+	default:
+		b.WriteString(orderedType(item.Type, item.ByteOrder))
+	}
+
+	if !item.IsArray {
+		return
+	}
+	switch {
+	case !item.IsVariableArray():
+		fmt.Fprintf(b, "[%d]", item.Length)
+	case item.LengthField != "":
+		// The counting item is identified by position, not name
+		for i := range container.Items {
+			if container.Items[i].Name == item.LengthField {
+				fmt.Fprintf(b, "[<=%d@%d]", item.MaxLength, i)
+			}
+		}
+	default:
+		fmt.Fprintf(b, "[<=%d:%s]", item.MaxLength, orderedType(item.LengthType, item.ByteOrder))
+	}
+}
+
+// orderedType returns a primitive type with its byte order, which only
+// multi-byte types carry
+func orderedType(itemType, byteOrder string) string {
+	if PrimitiveSize(itemType) <= 1 {
+		return itemType
+	}
+	if byteOrder == "big" {
+		return itemType + "be"
+	}
+	return itemType + "le"
+}
@@ -0,0 +1,214 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// Load reads a config file, validates it against the JSON Schema at
+// schemaFile and returns the parsed config with defaults applied
+func Load(configFile, schemaFile string) (*Config, error) {
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	return parse(configFile, data, schemaFile)
+}
+
+// Parse validates config data against the JSON Schema at schemaFile and
+// returns the parsed config after Validate
+func Parse(data []byte, schemaFile string) (*Config, error) {
+	return parse("", data, schemaFile)
+}
+
+// parse runs Diagnose and turns error problems into a *ValidationError
+func parse(file string, data []byte, schemaFile string) (*Config, error) {
+	config, problems, err := Diagnose(file, data, schemaFile)
+	if err != nil {
+		return nil, err
+	}
+	if HasErrors(problems) {
+		return nil, &ValidationError{Problems: problems}
+	}
+	return config, nil
+}
+
+// Diagnose checks config data read from file against the JSON Schema at
+// schemaFile and the semantic rules and returns every problem found, located
+// in the source. The config is nil when any problem is an error. The error
+// reports failures unrelated to the config, such as an unreadable schema.
+func Diagnose(file string, data []byte, schemaFile string) (*Config, []Problem, error) {
+	compiler := jsonschema.NewCompiler()
+	schema, err := compiler.Compile(schemaFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to compile schema: %w", err)
+	}
+
+	var problems problemList
+	var jsonData interface{}
+	if err := json.Unmarshal(data, &jsonData); err != nil {
+		problems = append(problems, syntaxProblem(err, data))
+		locate(problems, file, nil)
+		return nil, problems, nil
+	}
+	if err := schema.Validate(jsonData); err != nil {
+		var schemaErr *jsonschema.ValidationError
+		if !errors.As(err, &schemaErr) {
+			return nil, nil, fmt.Errorf("validation error: %w", err)
+		}
+		problems.addSchemaErrors(schemaErr)
+		locate(problems, file, data)
+		return nil, problems, nil
+	}
+
+	config := &Config{file: file, source: data}
+	if err := json.Unmarshal(data, config); err != nil {
+		problems = append(problems, syntaxProblem(err, data))
+		locate(problems, file, nil)
+		return nil, problems, nil
+	}
+	problems = config.check()
+	locate(problems, file, data)
+	if HasErrors(problems) {
+		return nil, problems, nil
+	}
+	config.resolveReferences()
+	return config, problems, nil
+}
+
+// Validate checks the constraints of the JSON Schema, fills in defaults,
+// checks the references and semantic rules the JSON Schema cannot express and
+// links each item to the enum or container it names. When any problem is an
+// error, every problem found is reported in a *ValidationError. Configs built
+// in code rather than loaded must be validated before they are generated or
+// encoded.
+func Validate(config *Config) error {
+	// Like Diagnose, stop at schema violations, which the other checks
+	// assume cannot happen
+	var problems problemList
+	config.checkStructure(&problems)
+	if err := problems.err(); err != nil {
+		return err
+	}
+	if err := config.check().err(); err != nil {
+		return err
+	}
+	config.resolveReferences()
+	return nil
+}
+
+// check fills in defaults and returns the reference and semantic problems
+func (c *Config) check() problemList {
+	c.applyDefaults()
+	var problems problemList
+	c.checkReferences(&problems)
+	c.checkSemantics(&problems)
+	return problems
+}
+
+// applyDefaults fills in the optional fields the schema gives defaults for
+func (c *Config) applyDefaults() {
+	for i := range c.Enums {
+		if c.Enums[i].Type == "" {
+			c.Enums[i].Type = "uint8"
+		}
+	}
+	for i := range c.Containers {
+		for j := range c.Containers[i].Items {
+			item := &c.Containers[i].Items[j]
+			if item.Type == "string" && item.Encoding == "" {
+				item.Encoding = "fixed"
+			}
+			if item.LengthType == "" {
+				item.LengthType = "uint8"
+			}
+			for k := range item.Bits {
+				if item.Bits[k].Width == 0 {
+					item.Bits[k].Width = 1
+				}
+			}
+		}
+	}
+}
+
+// checkReferences reports enum, container and lengthField references that do
+// not resolve and containers that nest themselves
+func (c *Config) checkReferences(problems *problemList) {
+	for ci, container := range c.Containers {
+		for i, item := range container.Items {
+			path := fmt.Sprintf("/containers/%d/items/%d", ci, i)
+			if item.Type == "enum" && c.Enum(item.Enum) == nil {
+				problems.add("unknown-enum", path+"/enum", "item %s.%s references unknown enum %q", container.Name, item.Name, item.Enum)
+			}
+			if item.Type == "container" && c.Container(item.Container) == nil {
+				problems.add("unknown-container", path+"/container", "item %s.%s references unknown container %q", container.Name, item.Name, item.Container)
+			}
+
+			// Variable-length arrays counted by another item need that item
+			// to be an integer scalar decoded before the array itself
+			if item.LengthField != "" {
+				counted := false
+				for _, prev := range container.Items[:i] {
+					if prev.Name == item.LengthField && !prev.IsArray && IsIntegerType(prev.Type) {
+						counted = true
+						break
+					}
+				}
+				if !counted {
+					problems.add("length-field", path+"/lengthField", "item %s.%s lengthField %q must name a preceding integer item", container.Name, item.Name, item.LengthField)
+				}
+			}
+		}
+	}
+
+	for i := range c.Containers {
+		if cycle := c.findContainerCycle(&c.Containers[i], nil); cycle != nil {
+			problems.add("container-cycle", fmt.Sprintf("/containers/%d", i), "container %s nests itself: %s", c.Containers[i].Name, strings.Join(cycle, " -> "))
+		}
+	}
+}
+
+// findContainerCycle returns the chain of container names leading back to a
+// container already on the path, or nil if the container does not nest itself
+func (c *Config) findContainerCycle(container *Container, path []string) []string {
+	for _, name := range path {
+		if name == container.Name {
+			return append(path, container.Name)
+		}
+	}
+	path = append(path, container.Name)
+	for _, item := range container.Items {
+		if item.Type != "container" {
+			continue
+		}
+		nested := c.Container(item.Container)
+		if nested == nil {
+			continue
+		}
+		if cycle := c.findContainerCycle(nested, path); cycle != nil {
+			return cycle
+		}
+	}
+	return nil
+}
+
+// resolveReferences sets the EnumType and ContainerType of every item
+func (c *Config) resolveReferences() {
+	for i := range c.Containers {
+		for j := range c.Containers[i].Items {
+			item := &c.Containers[i].Items[j]
+			item.EnumType, item.ContainerType = nil, nil
+			switch item.Type {
+			case "enum":
+				item.EnumType = c.Enum(item.Enum)
+			case "container":
+				item.ContainerType = c.Container(item.Container)
+			}
+		}
+	}
+}
@@ -0,0 +1,129 @@
+// Package model holds the uscdl configuration model shared by the code
+// generators and the runtime codec.
+package model
+
+// Item represents a property within a container
+type Item struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Enum        string `json:"enum"`
+	BaseType    string `json:"baseType"`
+	Bits        []Bit  `json:"bits"`
+	Container   string `json:"container"`
+	Encoding    string `json:"encoding"`
+	MaxLength   int    `json:"maxLength"`
+	LengthType  string `json:"lengthType"`
+	LengthField string `json:"lengthField"`
+	Description string `json:"description"`
+	ByteOrder   string `json:"byteOrder"`
+	Units       string `json:"units"`
+	IsArray     bool   `json:"isArray"`
+	Length      int    `json:"length"`
+
+	// EnumType and ContainerType point at the definitions named by Enum and
+	// Container once the config has been validated
+	EnumType      *Enum      `json:"-"`
+	ContainerType *Container `json:"-"`
+}
+
+// Bit represents a named bit or multi-bit sub-field of a bitfield item
+type Bit struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Offset      int    `json:"offset"`
+	Width       int    `json:"width"`
+}
+
+// Container represents a struct that contains multiple items
+type Container struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Items       []Item `json:"items"`
+}
+
+// EnumValue represents a single named value of an enumeration
+type EnumValue struct {
+	Name        string `json:"name"`
+	Value       int    `json:"value"`
+	Description string `json:"description"`
+}
+
+// Enum represents a named enumeration that items can reference
+type Enum struct {
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Type        string      `json:"type"`
+	Values      []EnumValue `json:"values"`
+}
+
+// Config represents the entire configuration
+type Config struct {
+	Containers []Container `json:"containers"`
+	Enums      []Enum      `json:"enums"`
+
+	// file and source locate problems found after loading
+	file   string
+	source []byte
+}
+
+// Container returns the container with the given name, or nil
+func (c *Config) Container(name string) *Container {
+	for i := range c.Containers {
+		if c.Containers[i].Name == name {
+			return &c.Containers[i]
+		}
+	}
+	return nil
+}
+
+// Enum returns the enumeration with the given name, or nil
+func (c *Config) Enum(name string) *Enum {
+	for i := range c.Enums {
+		if c.Enums[i].Name == name {
+			return &c.Enums[i]
+		}
+	}
+	return nil
+}
+
+// Item returns the item with the given name, or nil
+func (c *Container) Item(name string) *Item {
+	for i := range c.Items {
+		if c.Items[i].Name == name {
+			return &c.Items[i]
+		}
+	}
+	return nil
+}
+
+// IsVariableArray reports whether an item is an array bounded by maxLength
+// whose element count travels on the wire
+func (i *Item) IsVariableArray() bool {
+	return i.IsArray && i.MaxLength > 0
+}
+
+// IsIntegerType reports whether a primitive type is an integer
+func IsIntegerType(itemType string) bool {
+	switch itemType {
+	case "uint8", "uint16", "uint32", "uint64", "int8", "int16", "int32", "int64":
+		return true
+	}
+	return false
+}
+
+// PrimitiveSize returns the encoded size of a primitive type in bytes, or 0
+// for types that are not primitives
+func PrimitiveSize(itemType string) int {
+	switch itemType {
+	case "uint8", "int8", "bool":
+		return 1
+	case "uint16", "int16":
+		return 2
+	case "uint32", "int32", "float":
+		return 4
+	case "uint64", "int64", "double":
+		return 8
+	default:
+		return 0
+	}
+}
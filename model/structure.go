@@ -0,0 +1,139 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// namePattern is the pattern the JSON Schema requires of every name
+var namePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// Types the JSON Schema allows in each position
+var (
+	itemTypes = []string{"uint8", "uint16", "uint32", "uint64", "int8", "int16", "int32", "int64",
+		"float", "double", "bool", "string", "enum", "bitfield", "container"}
+	enumTypes   = []string{"uint8", "uint16", "uint32", "int8", "int16", "int32"}
+	baseTypes   = []string{"uint8", "uint16", "uint32"}
+	lengthTypes = []string{"uint8", "uint16", "uint32"}
+	encodings   = []string{"fixed", "prefixed"}
+	byteOrders  = []string{"little", "big"}
+)
+
+// oneOfMessage reports a value outside a list, like the schema's enum keyword
+const oneOfMessage = "value must be one of %q"
+
+// oneOf reports whether a value is in a list
+func oneOf(value string, list []string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// checkStructure reports the violations of the JSON Schema constraints in a
+// config built in code, which never went through the schema. Empty optional
+// fields stand for their defaults. The problems use the schema rule, like
+// those found by the schema itself.
+func (c *Config) checkStructure(problems *problemList) {
+	if len(c.Containers) == 0 {
+		problems.add("schema", "/containers", "at least one container is required")
+	}
+	for e, enum := range c.Enums {
+		path := fmt.Sprintf("/enums/%d", e)
+		problems.checkPattern(path+"/name", enum.Name)
+		if enum.Type != "" && !oneOf(enum.Type, enumTypes) {
+			problems.add("schema", path+"/type", oneOfMessage, enumTypes)
+		}
+		if len(enum.Values) == 0 {
+			problems.add("schema", path+"/values", "at least one value is required")
+		}
+		for v, value := range enum.Values {
+			problems.checkPattern(fmt.Sprintf("%s/values/%d/name", path, v), value.Name)
+		}
+	}
+	for ci, container := range c.Containers {
+		path := fmt.Sprintf("/containers/%d", ci)
+		problems.checkPattern(path+"/name", container.Name)
+		if len(container.Items) == 0 {
+			problems.add("schema", path+"/items", "at least one item is required")
+		}
+		for i := range container.Items {
+			problems.checkItemStructure(fmt.Sprintf("%s/items/%d", path, i), &container.Items[i])
+		}
+	}
+}
+
+// checkItemStructure reports the schema violations of one item
+func (l *problemList) checkItemStructure(path string, item *Item) {
+	l.checkPattern(path+"/name", item.Name)
+	if !oneOf(item.Type, itemTypes) {
+		l.add("schema", path+"/type", oneOfMessage, itemTypes)
+	}
+
+	switch item.Type {
+	case "enum":
+		if item.Enum == "" {
+			l.add("schema", path, "missing properties: 'enum'")
+		}
+	case "container":
+		if item.Container == "" {
+			l.add("schema", path, "missing properties: 'container'")
+		}
+	case "bitfield":
+		if !oneOf(item.BaseType, baseTypes) {
+			l.add("schema", path+"/baseType", oneOfMessage, baseTypes)
+		}
+		if len(item.Bits) == 0 {
+			l.add("schema", path+"/bits", "at least one bit is required")
+		}
+		if item.IsArray {
+			l.add("schema", path+"/isArray", "bitfields cannot be arrays")
+		}
+	case "string":
+		if item.MaxLength < 1 {
+			l.add("schema", path+"/maxLength", "strings require a maxLength of at least 1")
+		}
+		if item.IsArray {
+			l.add("schema", path+"/isArray", "strings cannot be arrays")
+		}
+	}
+
+	if item.Encoding != "" && !oneOf(item.Encoding, encodings) {
+		l.add("schema", path+"/encoding", oneOfMessage, encodings)
+	}
+	if item.LengthType != "" && !oneOf(item.LengthType, lengthTypes) {
+		l.add("schema", path+"/lengthType", oneOfMessage, lengthTypes)
+	}
+	if item.ByteOrder != "" && !oneOf(item.ByteOrder, byteOrders) {
+		l.add("schema", path+"/byteOrder", oneOfMessage, byteOrders)
+	}
+	if item.MaxLength < 0 {
+		l.add("schema", path+"/maxLength", "must be >= 1")
+	}
+	if item.Length < 0 {
+		l.add("schema", path+"/length", "must be >= 1")
+	}
+	if item.LengthField != "" && (!item.IsArray || item.MaxLength < 1) {
+		l.add("schema", path+"/lengthField", "lengthField requires isArray and maxLength")
+	}
+
+	for b, bit := range item.Bits {
+		bitPath := fmt.Sprintf("%s/bits/%d", path, b)
+		l.checkPattern(bitPath+"/name", bit.Name)
+		if bit.Offset < 0 || bit.Offset > 31 {
+			l.add("schema", bitPath+"/offset", "must be between 0 and 31")
+		}
+		if bit.Width < 0 || bit.Width > 32 {
+			l.add("schema", bitPath+"/width", "must be between 1 and 32")
+		}
+	}
+}
+
+// checkPattern reports a name that does not match the schema's name pattern
+func (l *problemList) checkPattern(path, name string) {
+	if !namePattern.MatchString(name) {
+		l.add("schema", path, "name %q does not match pattern %q", name, namePattern.String())
+	}
+}
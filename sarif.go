@@ -0,0 +1,126 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"path/filepath"
+
+	"github.com/sammyjroberts/uscdl/model"
+)
+
+// SARIF 2.1.0 log structure, limited to the properties uscdl reports
+type (
+	sarifLog struct {
+		Schema  string     `json:"$schema"`
+		Version string     `json:"version"`
+		Runs    []sarifRun `json:"runs"`
+	}
+	sarifRun struct {
+		Tool    sarifTool     `json:"tool"`
+		Results []sarifResult `json:"results"`
+		// ColumnKind says how region columns are counted; problem columns
+		// count runes, while SARIF defaults to UTF-16 code units
+		ColumnKind string `json:"columnKind"`
+	}
+	sarifTool struct {
+		Driver sarifDriver `json:"driver"`
+	}
+	sarifDriver struct {
+		Name           string      `json:"name"`
+		InformationURI string      `json:"informationUri"`
+		Rules          []sarifRule `json:"rules"`
+	}
+	sarifRule struct {
+		ID                   string             `json:"id"`
+		ShortDescription     sarifMessage       `json:"shortDescription"`
+		DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+	}
+	sarifConfiguration struct {
+		Level string `json:"level"`
+	}
+	sarifMessage struct {
+		Text string `json:"text"`
+	}
+	sarifResult struct {
+		RuleID    string          `json:"ruleId"`
+		RuleIndex int             `json:"ruleIndex"`
+		Level     string          `json:"level"`
+		Message   sarifMessage    `json:"message"`
+		Locations []sarifLocation `json:"locations,omitempty"`
+	}
+	sarifLocation struct {
+		PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+		LogicalLocations []sarifLogical         `json:"logicalLocations,omitempty"`
+	}
+	sarifPhysicalLocation struct {
+		ArtifactLocation sarifArtifact `json:"artifactLocation"`
+		Region           *sarifRegion  `json:"region,omitempty"`
+	}
+	sarifArtifact struct {
+		URI string `json:"uri"`
+	}
+	sarifRegion struct {
+		StartLine   int `json:"startLine"`
+		StartColumn int `json:"startColumn"`
+	}
+	sarifLogical struct {
+		FullyQualifiedName string `json:"fullyQualifiedName"`
+		Kind               string `json:"kind"`
+	}
+)
+
+// writeSARIF writes problems as a SARIF 2.1.0 log for code scanning tools
+func writeSARIF(w io.Writer, problems []model.Problem) error {
+	driver := sarifDriver{
+		Name:           "uscdl",
+		InformationURI: "https://github.com/sammyjroberts/uscdl",
+	}
+	ruleIndex := make(map[string]int)
+	for i, rule := range model.Rules {
+		ruleIndex[rule.ID] = i
+		driver.Rules = append(driver.Rules, sarifRule{
+			ID:                   rule.ID,
+			ShortDescription:     sarifMessage{rule.Description},
+			DefaultConfiguration: sarifConfiguration{rule.Severity},
+		})
+	}
+
+	results := make([]sarifResult, 0, len(problems))
+	for _, p := range problems {
+		result := sarifResult{
+			RuleID:    p.Rule,
+			RuleIndex: ruleIndex[p.Rule],
+			Level:     p.Severity,
+			Message:   sarifMessage{p.Message},
+		}
+		var location sarifLocation
+		if p.File != "" {
+			location.PhysicalLocation = &sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifact{filepath.ToSlash(p.File)},
+			}
+			if p.Line > 0 {
+				location.PhysicalLocation.Region = &sarifRegion{StartLine: p.Line, StartColumn: p.Column}
+			}
+		}
+		if p.Path != "" {
+			location.LogicalLocations = []sarifLogical{{FullyQualifiedName: p.Path, Kind: "object"}}
+		}
+		if location.PhysicalLocation != nil || location.LogicalLocations != nil {
+			result.Locations = []sarifLocation{location}
+		}
+		results = append(results, result)
+	}
+
+	log := sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool:       sarifTool{Driver: driver},
+			Results:    results,
+			ColumnKind: "unicodeCodePoints",
+		}},
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(log)
+}
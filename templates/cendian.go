@@ -0,0 +1,239 @@
+package templates
+
+import (
+	"text/template"
+)
+
+// CEndianTemplate generates the shared header of portable byte-order helpers
+// used by every generated C source file. Values are packed with explicit
+// shifts so the wire format does not depend on the host's endianness.
+var CEndianTemplate = template.Must(template.New("cendian").Funcs(templateFuncs).Parse(`/**
+* uscdl_endian.h
+* Portable helpers for reading and writing integers and floats in a fixed byte order
+*/
+
+#ifndef USCDL_ENDIAN_H
+#define USCDL_ENDIAN_H
+
+#include <stdint.h>
+#include <stdbool.h>
+#include <string.h>
+
+static inline void uscdl_put_u8(uint8_t* p, uint8_t v) {
+    p[0] = v;
+}
+
+static inline uint8_t uscdl_get_u8(const uint8_t* p) {
+    return p[0];
+}
+
+static inline void uscdl_put_i8(uint8_t* p, int8_t v) {
+    p[0] = (uint8_t)v;
+}
+
+static inline int8_t uscdl_get_i8(const uint8_t* p) {
+    return (int8_t)p[0];
+}
+
+static inline void uscdl_put_bool(uint8_t* p, bool v) {
+    p[0] = v ? 1u : 0u;
+}
+
+static inline bool uscdl_get_bool(const uint8_t* p) {
+    return p[0] != 0;
+}
+
+static inline void uscdl_put_u16_le(uint8_t* p, uint16_t v) {
+    p[0] = (uint8_t)v;
+    p[1] = (uint8_t)(v >> 8);
+}
+
+static inline uint16_t uscdl_get_u16_le(const uint8_t* p) {
+    return (uint16_t)(((uint16_t)p[0])
+        | ((uint16_t)p[1] << 8));
+}
+
+static inline void uscdl_put_u16_be(uint8_t* p, uint16_t v) {
+    p[0] = (uint8_t)(v >> 8);
+    p[1] = (uint8_t)v;
+}
+
+static inline uint16_t uscdl_get_u16_be(const uint8_t* p) {
+    return (uint16_t)(((uint16_t)p[0] << 8)
+        | ((uint16_t)p[1]));
+}
+
+static inline void uscdl_put_u32_le(uint8_t* p, uint32_t v) {
+    p[0] = (uint8_t)v;
+    p[1] = (uint8_t)(v >> 8);
+    p[2] = (uint8_t)(v >> 16);
+    p[3] = (uint8_t)(v >> 24);
+}
+
+static inline uint32_t uscdl_get_u32_le(const uint8_t* p) {
+    return (uint32_t)(((uint32_t)p[0])
+        | ((uint32_t)p[1] << 8)
+        | ((uint32_t)p[2] << 16)
+        | ((uint32_t)p[3] << 24));
+}
+
+static inline void uscdl_put_u32_be(uint8_t* p, uint32_t v) {
+    p[0] = (uint8_t)(v >> 24);
+    p[1] = (uint8_t)(v >> 16);
+    p[2] = (uint8_t)(v >> 8);
+    p[3] = (uint8_t)v;
+}
+
+static inline uint32_t uscdl_get_u32_be(const uint8_t* p) {
+    return (uint32_t)(((uint32_t)p[0] << 24)
+        | ((uint32_t)p[1] << 16)
+        | ((uint32_t)p[2] << 8)
+        | ((uint32_t)p[3]));
+}
+
+static inline void uscdl_put_u64_le(uint8_t* p, uint64_t v) {
+    p[0] = (uint8_t)v;
+    p[1] = (uint8_t)(v >> 8);
+    p[2] = (uint8_t)(v >> 16);
+    p[3] = (uint8_t)(v >> 24);
+    p[4] = (uint8_t)(v >> 32);
+    p[5] = (uint8_t)(v >> 40);
+    p[6] = (uint8_t)(v >> 48);
+    p[7] = (uint8_t)(v >> 56);
+}
+
+static inline uint64_t uscdl_get_u64_le(const uint8_t* p) {
+    return (uint64_t)(((uint64_t)p[0])
+        | ((uint64_t)p[1] << 8)
+        | ((uint64_t)p[2] << 16)
+        | ((uint64_t)p[3] << 24)
+        | ((uint64_t)p[4] << 32)
+        | ((uint64_t)p[5] << 40)
+        | ((uint64_t)p[6] << 48)
+        | ((uint64_t)p[7] << 56));
+}
+
+static inline void uscdl_put_u64_be(uint8_t* p, uint64_t v) {
+    p[0] = (uint8_t)(v >> 56);
+    p[1] = (uint8_t)(v >> 48);
+    p[2] = (uint8_t)(v >> 40);
+    p[3] = (uint8_t)(v >> 32);
+    p[4] = (uint8_t)(v >> 24);
+    p[5] = (uint8_t)(v >> 16);
+    p[6] = (uint8_t)(v >> 8);
+    p[7] = (uint8_t)v;
+}
+
+static inline uint64_t uscdl_get_u64_be(const uint8_t* p) {
+    return (uint64_t)(((uint64_t)p[0] << 56)
+        | ((uint64_t)p[1] << 48)
+        | ((uint64_t)p[2] << 40)
+        | ((uint64_t)p[3] << 32)
+        | ((uint64_t)p[4] << 24)
+        | ((uint64_t)p[5] << 16)
+        | ((uint64_t)p[6] << 8)
+        | ((uint64_t)p[7]));
+}
+
+static inline void uscdl_put_i16_le(uint8_t* p, int16_t v) {
+    uscdl_put_u16_le(p, (uint16_t)v);
+}
+
+static inline int16_t uscdl_get_i16_le(const uint8_t* p) {
+    return (int16_t)uscdl_get_u16_le(p);
+}
+
+static inline void uscdl_put_i16_be(uint8_t* p, int16_t v) {
+    uscdl_put_u16_be(p, (uint16_t)v);
+}
+
+static inline int16_t uscdl_get_i16_be(const uint8_t* p) {
+    return (int16_t)uscdl_get_u16_be(p);
+}
+
+static inline void uscdl_put_i32_le(uint8_t* p, int32_t v) {
+    uscdl_put_u32_le(p, (uint32_t)v);
+}
+
+static inline int32_t uscdl_get_i32_le(const uint8_t* p) {
+    return (int32_t)uscdl_get_u32_le(p);
+}
+
+static inline void uscdl_put_i32_be(uint8_t* p, int32_t v) {
+    uscdl_put_u32_be(p, (uint32_t)v);
+}
+
+static inline int32_t uscdl_get_i32_be(const uint8_t* p) {
+    return (int32_t)uscdl_get_u32_be(p);
+}
+
+static inline void uscdl_put_i64_le(uint8_t* p, int64_t v) {
+    uscdl_put_u64_le(p, (uint64_t)v);
+}
+
+static inline int64_t uscdl_get_i64_le(const uint8_t* p) {
+    return (int64_t)uscdl_get_u64_le(p);
+}
+
+static inline void uscdl_put_i64_be(uint8_t* p, int64_t v) {
+    uscdl_put_u64_be(p, (uint64_t)v);
+}
+
+static inline int64_t uscdl_get_i64_be(const uint8_t* p) {
+    return (int64_t)uscdl_get_u64_be(p);
+}
+
+static inline void uscdl_put_f32_le(uint8_t* p, float v) {
+    uint32_t bits;
+    memcpy(&bits, &v, sizeof(bits));
+    uscdl_put_u32_le(p, bits);
+}
+
+static inline float uscdl_get_f32_le(const uint8_t* p) {
+    uint32_t bits = uscdl_get_u32_le(p);
+    float v;
+    memcpy(&v, &bits, sizeof(v));
+    return v;
+}
+
+static inline void uscdl_put_f32_be(uint8_t* p, float v) {
+    uint32_t bits;
+    memcpy(&bits, &v, sizeof(bits));
+    uscdl_put_u32_be(p, bits);
+}
+
+static inline float uscdl_get_f32_be(const uint8_t* p) {
+    uint32_t bits = uscdl_get_u32_be(p);
+    float v;
+    memcpy(&v, &bits, sizeof(v));
+    return v;
+}
+
+static inline void uscdl_put_f64_le(uint8_t* p, double v) {
+    uint64_t bits;
+    memcpy(&bits, &v, sizeof(bits));
+    uscdl_put_u64_le(p, bits);
+}
+
+static inline double uscdl_get_f64_le(const uint8_t* p) {
+    uint64_t bits = uscdl_get_u64_le(p);
+    double v;
+    memcpy(&v, &bits, sizeof(v));
+    return v;
+}
+
+static inline void uscdl_put_f64_be(uint8_t* p, double v) {
+    uint64_t bits;
+    memcpy(&bits, &v, sizeof(bits));
+    uscdl_put_u64_be(p, bits);
+}
+
+static inline double uscdl_get_f64_be(const uint8_t* p) {
+    uint64_t bits = uscdl_get_u64_be(p);
+    double v;
+    memcpy(&v, &bits, sizeof(v));
+    return v;
+}
+
+#endif /* USCDL_ENDIAN_H */
+`))
@@ -0,0 +1,55 @@
+package templates
+
+import (
+	"text/template"
+)
+
+// CEnumTemplate generates a C header file with an enumeration and its range check
+var CEnumTemplate = template.Must(template.New("cenum").Funcs(templateFuncs).Parse(`/**
+* {{.Name}}
+* {{.Description}}
+*/
+
+#ifndef {{.Name | ToUpper}}_H
+#define {{.Name | ToUpper}}_H
+
+#include <stdint.h>
+#include <stdbool.h>
+
+/**
+* {{.Description}}
+* Encoded on the wire as {{.Type}}
+*/
+{{- if EnumFitsCInt .}}
+typedef enum {
+{{- range .Values}}
+    /* {{.Description}} */
+    {{EnumConstantC $ .}} = {{.Value}},
+{{- end}}
+} {{.Name}}_t;
+{{- else}}
+typedef {{GetCTypeName .Type}} {{.Name}}_t;
+{{range .Values}}
+/* {{.Description}} */
+#define {{EnumConstantC $ .}} (({{$.Name}}_t){{.Value}}u)
+{{- end}}
+{{- end}}
+
+/**
+* Check whether a raw wire value is a legal {{.Name}}
+* @param value The raw value to check
+* @return true if the value names a {{.Name}} member
+*/
+static inline bool {{.Name | ToSnakeCase}}_is_valid({{GetCTypeName .Type}} value) {
+    switch (value) {
+{{- range .Values}}
+    case {{.Value}}:
+{{- end}}
+        return true;
+    default:
+        return false;
+    }
+}
+
+#endif /* {{.Name | ToUpper}}_H */
+`))
@@ -0,0 +1,60 @@
+package templates
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// renderCEnum renders the C enum header of a uint32 enumeration with a single
+// value
+func renderCEnum(t *testing.T, value int) string {
+	t.Helper()
+	enum := &Enum{
+		Name:        "Wide",
+		Description: "wide enum",
+		Type:        "uint32",
+		Values:      []EnumValue{{Name: "Top", Value: value, Description: "top"}},
+	}
+	var out strings.Builder
+	if err := CEnumTemplate.Execute(&out, enum); err != nil {
+		t.Fatalf("failed to render enum: %v", err)
+	}
+	return out.String()
+}
+
+func TestCEnumIntBoundary(t *testing.T) {
+	tests := []struct {
+		value int
+		want  string
+	}{
+		{2147483647, "WIDE_TOP = 2147483647,"},
+		{2147483648, "#define WIDE_TOP ((Wide_t)2147483648u)"},
+		{4294967295, "#define WIDE_TOP ((Wide_t)4294967295u)"},
+	}
+	for _, test := range tests {
+		header := renderCEnum(t, test.value)
+		if !strings.Contains(header, test.want) {
+			t.Errorf("value %d: header does not contain %q:\n%s", test.value, test.want, header)
+		}
+	}
+}
+
+func TestCEnumPedantic(t *testing.T) {
+	gcc, err := exec.LookPath("gcc")
+	if err != nil {
+		t.Skip("gcc not found")
+	}
+	for _, value := range []int{2147483647, 2147483648, 4294967295} {
+		file := filepath.Join(t.TempDir(), "wide.h")
+		if err := os.WriteFile(file, []byte(renderCEnum(t, value)), 0644); err != nil {
+			t.Fatal(err)
+		}
+		out, err := exec.Command(gcc, "-std=c99", "-pedantic", "-Werror", "-fsyntax-only", file).CombinedOutput()
+		if err != nil {
+			t.Errorf("value %d: gcc rejected the header: %v\n%s", value, err, out)
+		}
+	}
+}
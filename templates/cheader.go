@@ -7,21 +7,96 @@ import (
 
 // Helper functions for the template
 var templateFuncs = template.FuncMap{
-	"ToUpper":             strings.ToUpper,
-	"ToLower":             strings.ToLower,
-	"ToSnakeCase":         ToSnakeCase,
-	"GetCType":            GetCType,
-	"GetTypeSizeC":        GetTypeSizeC,
-	"GetDefaultValueC":    GetDefaultValueC,
-	"CalculateStructSize": CalculateStructSize,
-	"NeedsByteSwap":       NeedsByteSwap,
-	"GetTSType":           GetTSType,
-	"GetDefaultValueTS":   GetDefaultValueTS,
+	"ToUpper":                strings.ToUpper,
+	"ToLower":                strings.ToLower,
+	"ToSnakeCase":            ToSnakeCase,
+	"CFileName":              strings.ToLower,
+	"TSFileName":             func(name string) string { return name },
+	"GetCType":               GetCType,
+	"GetCTypeName":           GetCTypeName,
+	"GetTypeSizeC":           GetTypeSizeC,
+	"GetDefaultValueC":       GetDefaultValueC,
+	"CalculateStructSize":    CalculateStructSize,
+	"GetTSType":              GetTSType,
+	"GetDefaultValueTS":      GetDefaultValueTS,
+	"WireType":               WireType,
+	"GetDataViewType":        GetDataViewType,
+	"GetTSLittleEndianArg":   GetTSLittleEndianArg,
+	"GetTSLengthEndianArg":   GetTSLengthEndianArg,
+	"EnumConstantC":          EnumConstantC,
+	"EnumDefaultC":           EnumDefaultC,
+	"EnumFitsCInt":           EnumFitsCInt,
+	"EnumDefaultTS":          EnumDefaultTS,
+	"UsedEnums":              UsedEnums,
+	"UsedContainers":         UsedContainers,
+	"CalculateMinStructSize": CalculateMinStructSize,
+	"IsVariableArray":        IsVariableArray,
+	"ArrayCapacity":          ArrayCapacity,
+	"ArrayBoundC":            ArrayBoundC,
+	"ArrayBoundTS":           ArrayBoundTS,
+	"ArrayCountMemberC":      ArrayCountMemberC,
+	"PrimitiveSize":          PrimitiveSize,
+	"LayoutFingerprint":      LayoutFingerprint,
+	"Element":                Element,
+	"CPutFunc":               CPutFunc,
+	"CGetFunc":               CGetFunc,
+	"Indent":                 Indent,
+	"ItemOffsets":            ItemOffsets,
+	"StructSize":             StructSize,
+	"MinStructSize":          MinStructSize,
+	"ItemSize":               ItemSize,
+	"ItemMinSize":            ItemMinSize,
+	"DocTypeLabel":           DocTypeLabel,
+	"MarkdownEscape":         MarkdownEscape,
+	"GoName":                 GoName,
+	"GoTypeName":             GoTypeName,
+	"GetGoType":              GetGoType,
+	"GetGoFieldType":         GetGoFieldType,
+	"GetGoTags":              GetGoTags,
+	"GoPut":                  GoPut,
+	"GoGet":                  GoGet,
+	"GoImports":              GoImports,
+	"EnumDefaultGo":          EnumDefaultGo,
+	"RustName":               RustName,
+	"RustFieldName":          RustFieldName,
+	"RustTypeName":           RustTypeName,
+	"GetRustType":            GetRustType,
+	"GetRustFieldType":       GetRustFieldType,
+	"GetDefaultValueRust":    GetDefaultValueRust,
+	"EnumDefaultRust":        EnumDefaultRust,
+	"RustEncodeBytes":        RustEncodeBytes,
+	"RustDecodeValue":        RustDecodeValue,
+	"PyName":                 PyName,
+	"PyFieldName":            PyFieldName,
+	"PyStructFormat":         PyStructFormat,
+	"GetPyType":              GetPyType,
+	"GetPyFieldType":         GetPyFieldType,
+	"GetDefaultValuePy":      GetDefaultValuePy,
+	"EnumDefaultPy":          EnumDefaultPy,
+	"BitMacroName":           BitMacroName,
+	"BitMask":                BitMask,
+	"BitValueMask":           BitValueMask,
+	"GetBitCType":            GetBitCType,
+	"BitNeedsRangeCheck":     BitNeedsRangeCheck,
 	"sub": func(a, b int) int {
 		return a - b
 	},
 }
 
+// withInclude adds an "include" function to a template that renders one of its
+// named sub-templates to a string, so the result can be piped through Indent
+func withInclude(t *template.Template) *template.Template {
+	return t.Funcs(template.FuncMap{
+		"include": func(name string, data interface{}) (string, error) {
+			var buf strings.Builder
+			if err := t.ExecuteTemplate(&buf, name, data); err != nil {
+				return "", err
+			}
+			return buf.String(), nil
+		},
+	})
+}
+
 // CHeaderTemplate generates a simple C header file with struct definitions
 var CHeaderTemplate = template.Must(template.New("cheader").Funcs(templateFuncs).Parse(`/**
 * {{.Name}}
@@ -33,30 +108,135 @@ var CHeaderTemplate = template.Must(template.New("cheader").Funcs(templateFuncs)
 
 #include <stdint.h>
   #include <stdbool.h>
+  #include <stddef.h>
+{{- range UsedEnums .}}
+  #include "{{CFileName .Name}}.h"
+{{- end}}
+{{- range UsedContainers .}}
+  #include "{{CFileName .Name}}.h"
+{{- end}}
 
     /**
     * {{.Description}}
     */
     typedef struct {
     {{- range .Items}}
+    {{- if and (IsVariableArray .) (not .LengthField)}}
+    /* Number of valid elements in {{.Name}} */
+    {{GetCTypeName .LengthType}} {{ArrayCountMemberC .}};
+    {{- end}}
     {{- if .Units}}
     /* {{.Description}} ({{.Units}}) */
     {{- else}}
     /* {{.Description}} */
     {{- end}}
-    {{- if .IsArray}}
-    {{GetCType .}} {{.Name}}[{{.Length}}];
+    {{- if eq .Type "string"}}
+    char {{.Name}}[{{.MaxLength}} + 1];
+    {{- else if .IsArray}}
+    {{GetCType .}} {{.Name}}[{{ArrayCapacity .}}];
     {{- else}}
     {{GetCType .}} {{.Name}};
     {{- end}}
     {{- end}}
     } {{.Name}}_t;
 
+    /* Serialized size bounds of {{.Name}} in bytes */
+    #define {{.Name | ToSnakeCase | ToUpper}}_MIN_SIZE {{CalculateMinStructSize .}}
+    #define {{.Name | ToSnakeCase | ToUpper}}_MAX_SIZE {{CalculateStructSize .}}
+
+    /* Fingerprint of the wire layout of {{.Name}}; peers built from a different layout have a different value */
+    #define {{.Name | ToSnakeCase | ToUpper}}_FINGERPRINT {{LayoutFingerprint .}}u
+    {{- range $item := .Items}}
+    {{- if eq .Type "bitfield"}}
+
+    /* Bits of {{$.Name}}.{{$item.Name}} */
+    {{- range .Bits}}
+    #define {{BitMacroName $.Name $item.Name .Name}}_SHIFT {{.Offset}}
+    #define {{BitMacroName $.Name $item.Name .Name}}_MASK {{BitMask .}}
+    {{- end}}
+    {{- range .Bits}}
+
+    /**
+    * Get {{$item.Name}}.{{.Name}}: {{.Description}}
+    * @param p_data Pointer to the structure to read
+    */
+    static inline {{GetBitCType $item .}} {{$.Name | ToSnakeCase}}_get_{{$item.Name | ToSnakeCase}}_{{.Name | ToSnakeCase}}(const {{$.Name}}_t* p_data) {
+    {{- if eq (GetBitCType $item .) "bool"}}
+        return (p_data->{{$item.Name}} & {{BitMacroName $.Name $item.Name .Name}}_MASK) != 0;
+    {{- else}}
+        return ({{GetBitCType $item .}})((p_data->{{$item.Name}} & {{BitMacroName $.Name $item.Name .Name}}_MASK) >> {{BitMacroName $.Name $item.Name .Name}}_SHIFT);
+    {{- end}}
+    }
+
+    {{- if eq (GetBitCType $item .) "bool"}}
+
+    /**
+    * Set {{$item.Name}}.{{.Name}}: {{.Description}}
+    * @param p_data Pointer to the structure to modify
+    * @param value The new value
+    */
+    static inline void {{$.Name | ToSnakeCase}}_set_{{$item.Name | ToSnakeCase}}_{{.Name | ToSnakeCase}}({{$.Name}}_t* p_data, bool value) {
+        if (value) {
+            p_data->{{$item.Name}} |= {{BitMacroName $.Name $item.Name .Name}}_MASK;
+        } else {
+            p_data->{{$item.Name}} &= ({{GetCTypeName $item.BaseType}})~{{BitMacroName $.Name $item.Name .Name}}_MASK;
+        }
+    }
+    {{- else}}
+
+    /**
+    * Set {{$item.Name}}.{{.Name}}: {{.Description}}
+    * @param p_data Pointer to the structure to modify
+    * @param value The new value, at most {{BitValueMask .}}
+    * @return 0 on success, -1 if the value does not fit in {{.Width}} bits
+    */
+    static inline int {{$.Name | ToSnakeCase}}_set_{{$item.Name | ToSnakeCase}}_{{.Name | ToSnakeCase}}({{$.Name}}_t* p_data, {{GetBitCType $item .}} value) {
+    {{- if BitNeedsRangeCheck $item .}}
+        if (value > {{BitValueMask .}}u) {
+            return -1;
+        }
+    {{- end}}
+        p_data->{{$item.Name}} = ({{GetCTypeName $item.BaseType}})((p_data->{{$item.Name}} & ~{{BitMacroName $.Name $item.Name .Name}}_MASK) |
+            (((uint32_t)value << {{BitMacroName $.Name $item.Name .Name}}_SHIFT) & {{BitMacroName $.Name $item.Name .Name}}_MASK));
+        return 0;
+    }
+    {{- end}}
+    {{- end}}
+    {{- end}}
+    {{- end}}
+
     /**
     * Initialize a {{.Name}} structure with default values
     * @param p_data Pointer to the structure to initialize
     */
     void {{.Name | ToSnakeCase}}_init({{.Name}}_t* p_data);
 
+    /**
+    * Serialize a {{.Name}} structure into a buffer
+    * @param p_data Pointer to the structure to serialize
+    * @param buffer Destination buffer
+    * @param buffer_size Size of the destination buffer in bytes
+    * @return Number of bytes written, or -1 on error
+    */
+    int {{.Name | ToSnakeCase}}_serialize(const {{.Name}}_t* p_data, uint8_t* buffer, size_t buffer_size);
+
+    /**
+    * Deserialize a {{.Name}} structure from a buffer
+    * @param p_data Pointer to the structure to fill
+    * @param buffer Source buffer
+    * @param buffer_size Size of the source buffer in bytes
+    * @return Number of bytes read, or -1 on error
+    */
+    int {{.Name | ToSnakeCase}}_deserialize({{.Name}}_t* p_data, const uint8_t* buffer, size_t buffer_size);
+
+    /**
+    * Check a peer's {{.Name}} layout fingerprint against this build
+    * @param fingerprint The fingerprint reported by the peer
+    * @return true if both sides use the same wire layout
+    */
+    static inline bool {{.Name | ToSnakeCase}}_check_fingerprint(uint32_t fingerprint) {
+        return fingerprint == {{.Name | ToSnakeCase | ToUpper}}_FINGERPRINT;
+    }
+
     #endif /* {{.Name | ToUpper}}_H */
     `))
@@ -4,15 +4,17 @@ import (
 	"text/template"
 )
 
-// CSourceTemplate generates a C source file implementation with unique variable names
-var CSourceTemplate = template.Must(template.New("csource").Funcs(templateFuncs).Parse(`/**
+// CSourceTemplate generates a C source file implementation. Every value is
+// written through the uscdl_endian.h helpers chosen at generation time from the
+// item's wire type and byte order, so the output does not depend on the host.
+var CSourceTemplate = template.Must(withInclude(template.New("csource").Funcs(templateFuncs)).Parse(`/**
 * {{.Name}}
 * {{.Description}}
 */
 
-#include "{{.Name | ToLower}}.h"
+#include "{{CFileName .Name}}.h"
+#include "uscdl_endian.h"
 #include <string.h>
-#include <stdlib.h>
 
 void {{.Name | ToSnakeCase}}_init({{.Name}}_t* p_data) {
     if (p_data == NULL) {
@@ -20,7 +22,26 @@ void {{.Name | ToSnakeCase}}_init({{.Name}}_t* p_data) {
     }
 
     {{- range .Items}}
+    {{- if eq .Type "container"}}
     {{- if .IsArray}}
+    for (size_t i = 0; i < {{ArrayCapacity .}}; i++) {
+        {{.Container | ToSnakeCase}}_init(&p_data->{{.Name}}[i]);
+    }
+    {{- else}}
+    {{.Container | ToSnakeCase}}_init(&p_data->{{.Name}});
+    {{- end}}
+    {{- else if eq .Type "enum"}}
+    {{- if .IsArray}}
+    for (size_t i = 0; i < {{ArrayCapacity .}}; i++) {
+        p_data->{{.Name}}[i] = {{EnumDefaultC .EnumType}};
+    }
+    {{- else}}
+    p_data->{{.Name}} = {{EnumDefaultC .EnumType}};
+    {{- end}}
+    {{- else if or .IsArray (eq .Type "string")}}
+    {{- if and (IsVariableArray .) (not .LengthField)}}
+    p_data->{{ArrayCountMemberC .}} = 0;
+    {{- end}}
     memset(p_data->{{.Name}}, {{GetDefaultValueC .Type}}, sizeof(p_data->{{.Name}}));
     {{- else}}
     p_data->{{.Name}} = {{GetDefaultValueC .Type}};
@@ -33,125 +54,36 @@ int {{.Name | ToSnakeCase}}_serialize(const {{.Name}}_t* p_data, uint8_t* buffer
         return -1;
     }
 
-    // Ensure buffer is large enough
-    if (buffer_size < {{CalculateStructSize .}}) {
-        return -1;
-    }
-
     size_t offset = 0;
     uint8_t* ptr = buffer;
-    size_t item_size = 0;
 
-    {{- range $itemIndex, $item := .Items}}
+    {{- range .Items}}
     {{- if .IsArray}}
-    {{- if eq .Type "string"}}
-    // String arrays not supported in this simple implementation
-    {{- else if NeedsByteSwap .}}
-    // Copy {{.Name}} array with byte swapping
-    for (int i = 0; i < {{.Length}}; i++) {
-        switch ({{.Type}}) {
-        case "uint16", "int16":
-            {
-                uint16_t temp = SWAP_UINT16((uint16_t)p_data->{{.Name}}[i]);
-                memcpy(ptr + offset, &temp, sizeof(uint16_t));
-                offset += sizeof(uint16_t);
-            }
-            break;
-        case "uint32", "int32":
-            {
-                uint32_t temp = SWAP_UINT32((uint32_t)p_data->{{.Name}}[i]);
-                memcpy(ptr + offset, &temp, sizeof(uint32_t));
-                offset += sizeof(uint32_t);
-            }
-            break;
-        case "uint64", "int64":
-            {
-                uint64_t temp = SWAP_UINT64((uint64_t)p_data->{{.Name}}[i]);
-                memcpy(ptr + offset, &temp, sizeof(uint64_t));
-                offset += sizeof(uint64_t);
-            }
-            break;
-        case "float":
-            {
-                float temp = swap_float(p_data->{{.Name}}[i]);
-                memcpy(ptr + offset, &temp, sizeof(float));
-                offset += sizeof(float);
-            }
-            break;
-        case "double":
-            {
-                double temp = swap_double(p_data->{{.Name}}[i]);
-                memcpy(ptr + offset, &temp, sizeof(double));
-                offset += sizeof(double);
-            }
-            break;
-        }
+    {{- if IsVariableArray .}}
+
+    // {{.Name}}: up to {{.MaxLength}} elements{{if .LengthField}} counted by {{.LengthField}}{{else}} after a {{.LengthType}} count{{end}}
+    size_t {{.Name}}_count = (size_t)p_data->{{ArrayCountMemberC .}};
+    if ({{.Name}}_count > {{.MaxLength}}) {
+        return -1;
     }
-    {{- else}}
-    // Direct copy for little-endian or byte types
-    item_size = {{GetTypeSizeC .Type}} * {{.Length}};
-    memcpy(ptr + offset, p_data->{{.Name}}, item_size);
-    offset += item_size;
+    {{- if not .LengthField}}
+    if (offset + {{PrimitiveSize .LengthType}} > buffer_size) {
+        return -1;
+    }
+    {{CPutFunc .LengthType .ByteOrder}}(ptr + offset, ({{GetCTypeName .LengthType}}){{.Name}}_count);
+    offset += {{PrimitiveSize .LengthType}};
     {{- end}}
     {{- else}}
-    {{- if eq .Type "string"}}
-    // For non-array strings, assume null-terminated and copy until null or buffer exhausted
-    if (p_data->{{.Name}} != NULL) {
-        size_t str_len = strlen(p_data->{{.Name}});
-        if (offset + str_len + 1 > buffer_size) {
-            return -1;
-        }
-        memcpy(ptr + offset, p_data->{{.Name}}, str_len + 1);
-        offset += str_len + 1;
-    } else {
-        // Just write a null byte for NULL strings
-        *(ptr + offset) = '\0';
-        offset++;
-    }
-    {{- else if NeedsByteSwap .}}
-    // Handle byte swapping for multi-byte scalar values
-    switch ({{.Type}}) {
-    case "uint16", "int16":
-        {
-            uint16_t temp = SWAP_UINT16((uint16_t)p_data->{{.Name}});
-            memcpy(ptr + offset, &temp, sizeof(uint16_t));
-            offset += sizeof(uint16_t);
-        }
-        break;
-    case "uint32", "int32":
-        {
-            uint32_t temp = SWAP_UINT32((uint32_t)p_data->{{.Name}});
-            memcpy(ptr + offset, &temp, sizeof(uint32_t));
-            offset += sizeof(uint32_t);
-        }
-        break;
-    case "uint64", "int64":
-        {
-            uint64_t temp = SWAP_UINT64((uint64_t)p_data->{{.Name}});
-            memcpy(ptr + offset, &temp, sizeof(uint64_t));
-            offset += sizeof(uint64_t);
-        }
-        break;
-    case "float":
-        {
-            float temp = swap_float(p_data->{{.Name}});
-            memcpy(ptr + offset, &temp, sizeof(float));
-            offset += sizeof(float);
-        }
-        break;
-    case "double":
-        {
-            double temp = swap_double(p_data->{{.Name}});
-            memcpy(ptr + offset, &temp, sizeof(double));
-            offset += sizeof(double);
-        }
-        break;
+
+    // {{.Name}}: {{.Length}} elements
+    {{- end}}
+    for (size_t i = 0; i < {{ArrayBoundC .}}; i++) {
+{{include "serializeElement" (Element . (printf "p_data->%s[i]" .Name)) | Indent 8}}
     }
     {{- else}}
-    // Direct copy for little-endian or byte types
-    memcpy(ptr + offset, &p_data->{{.Name}}, {{GetTypeSizeC .Type}});
-    offset += {{GetTypeSizeC .Type}};
-    {{- end}}
+
+    // {{.Name}}
+{{include "serializeElement" (Element . (printf "p_data->%s" .Name)) | Indent 4}}
     {{- end}}
     {{- end}}
 
@@ -168,137 +100,142 @@ int {{.Name | ToSnakeCase}}_deserialize({{.Name}}_t* p_data, const uint8_t* buff
 
     size_t offset = 0;
     const uint8_t* ptr = buffer;
-    size_t item_size = 0;
 
-    {{- range $itemIndex, $item := .Items}}
+    {{- range .Items}}
     {{- if .IsArray}}
-    {{- if eq .Type "string"}}
-    // String arrays not supported in this simple implementation
-    {{- else if NeedsByteSwap .}}
-    // Copy array with byte swapping
-    for (int i = 0; i < {{.Length}}; i++) {
-        if (offset + {{GetTypeSizeC .Type}} > buffer_size) {
-            return -1;
-        }
+    {{- if IsVariableArray .}}
 
-        switch ({{.Type}}) {
-        case "uint16", "int16":
-            {
-                uint16_t temp;
-                memcpy(&temp, ptr + offset, sizeof(uint16_t));
-                p_data->{{.Name}}[i] = SWAP_UINT16(temp);
-                offset += sizeof(uint16_t);
-            }
-            break;
-        case "uint32", "int32":
-            {
-                uint32_t temp;
-                memcpy(&temp, ptr + offset, sizeof(uint32_t));
-                p_data->{{.Name}}[i] = SWAP_UINT32(temp);
-                offset += sizeof(uint32_t);
-            }
-            break;
-        case "uint64", "int64":
-            {
-                uint64_t temp;
-                memcpy(&temp, ptr + offset, sizeof(uint64_t));
-                p_data->{{.Name}}[i] = SWAP_UINT64(temp);
-                offset += sizeof(uint64_t);
-            }
-            break;
-        case "float":
-            {
-                float temp;
-                memcpy(&temp, ptr + offset, sizeof(float));
-                p_data->{{.Name}}[i] = swap_float(temp);
-                offset += sizeof(float);
-            }
-            break;
-        case "double":
-            {
-                double temp;
-                memcpy(&temp, ptr + offset, sizeof(double));
-                p_data->{{.Name}}[i] = swap_double(temp);
-                offset += sizeof(double);
-            }
-            break;
-        }
-    }
+    // {{.Name}}: up to {{.MaxLength}} elements{{if .LengthField}} counted by {{.LengthField}}{{else}} after a {{.LengthType}} count{{end}}
+    {{- if .LengthField}}
+    size_t {{.Name}}_count = (size_t)p_data->{{.LengthField}};
     {{- else}}
-    // Direct copy for little-endian or byte types
-    item_size = {{GetTypeSizeC .Type}} * {{.Length}};
-    if (offset + item_size > buffer_size) {
+    if (offset + {{PrimitiveSize .LengthType}} > buffer_size) {
         return -1;
     }
-    memcpy(p_data->{{.Name}}, ptr + offset, item_size);
-    offset += item_size;
+    size_t {{.Name}}_count = (size_t){{CGetFunc .LengthType .ByteOrder}}(ptr + offset);
+    offset += {{PrimitiveSize .LengthType}};
     {{- end}}
-    {{- else}}
-    {{- if eq .Type "string"}}
-    // For strings, we need to allocate memory and copy the string
-    // This implementation doesn't handle strings - would need memory management
-    // Just copy the pointer - assumes the buffer outlives the structure
-    p_data->{{.Name}} = (char*)(ptr + offset);
-    offset += strlen(p_data->{{.Name}}) + 1;
-    {{- else if NeedsByteSwap .}}
-    // Handle byte swapping for multi-byte values
-    if (offset + {{GetTypeSizeC .Type}} > buffer_size) {
+    if ({{.Name}}_count > {{.MaxLength}}) {
         return -1;
     }
+    {{- if not .LengthField}}
+    p_data->{{ArrayCountMemberC .}} = ({{GetCTypeName .LengthType}}){{.Name}}_count;
+    {{- end}}
+    {{- else}}
 
-    switch ({{.Type}}) {
-    case "uint16", "int16":
-        {
-            uint16_t temp;
-            memcpy(&temp, ptr + offset, sizeof(uint16_t));
-            p_data->{{.Name}} = SWAP_UINT16(temp);
-            offset += sizeof(uint16_t);
-        }
-        break;
-    case "uint32", "int32":
-        {
-            uint32_t temp;
-            memcpy(&temp, ptr + offset, sizeof(uint32_t));
-            p_data->{{.Name}} = SWAP_UINT32(temp);
-            offset += sizeof(uint32_t);
-        }
-        break;
-    case "uint64", "int64":
-        {
-            uint64_t temp;
-            memcpy(&temp, ptr + offset, sizeof(uint64_t));
-            p_data->{{.Name}} = SWAP_UINT64(temp);
-            offset += sizeof(uint64_t);
-        }
-        break;
-    case "float":
-        {
-            float temp;
-            memcpy(&temp, ptr + offset, sizeof(float));
-            p_data->{{.Name}} = swap_float(temp);
-            offset += sizeof(float);
-        }
-        break;
-    case "double":
-        {
-            double temp;
-            memcpy(&temp, ptr + offset, sizeof(double));
-            p_data->{{.Name}} = swap_double(temp);
-            offset += sizeof(double);
-        }
-        break;
+    // {{.Name}}: {{.Length}} elements
+    {{- end}}
+    for (size_t i = 0; i < {{ArrayBoundC .}}; i++) {
+{{include "deserializeElement" (Element . (printf "p_data->%s[i]" .Name)) | Indent 8}}
     }
     {{- else}}
-    // Direct copy for little-endian or byte types
-    if (offset + {{GetTypeSizeC .Type}} > buffer_size) {
-        return -1;
-    }
-    memcpy(&p_data->{{.Name}}, ptr + offset, {{GetTypeSizeC .Type}});
-    offset += {{GetTypeSizeC .Type}};
-    {{- end}}
+
+    // {{.Name}}
+{{include "deserializeElement" (Element . (printf "p_data->%s" .Name)) | Indent 4}}
     {{- end}}
     {{- end}}
 
     return (int)offset;
 }
+
+{{- define "serializeElement"}}
+{{- $item := .Item}}
+{{- if eq $item.Type "container"}}
+{
+    int written = {{$item.Container | ToSnakeCase}}_serialize(&{{.Expr}}, ptr + offset, buffer_size - offset);
+    if (written < 0) {
+        return -1;
+    }
+    offset += (size_t)written;
+}
+{{- else if eq $item.Type "string"}}
+{
+    size_t str_len = 0;
+    while (str_len < {{$item.MaxLength}} && {{.Expr}}[str_len] != '\0') {
+        str_len++;
+    }
+{{- if eq $item.Encoding "prefixed"}}
+    if (offset + {{PrimitiveSize $item.LengthType}} + str_len > buffer_size) {
+        return -1;
+    }
+    {{CPutFunc $item.LengthType $item.ByteOrder}}(ptr + offset, ({{GetCTypeName $item.LengthType}})str_len);
+    offset += {{PrimitiveSize $item.LengthType}};
+    memcpy(ptr + offset, {{.Expr}}, str_len);
+    offset += str_len;
+{{- else}}
+    if (offset + {{$item.MaxLength}} > buffer_size) {
+        return -1;
+    }
+    memcpy(ptr + offset, {{.Expr}}, str_len);
+    memset(ptr + offset + str_len, 0, {{$item.MaxLength}} - str_len);
+    offset += {{$item.MaxLength}};
+{{- end}}
+}
+{{- else}}
+if (offset + {{PrimitiveSize (WireType $item)}} > buffer_size) {
+    return -1;
+}
+{{- if eq $item.Type "enum"}}
+if (!{{$item.Enum | ToSnakeCase}}_is_valid(({{GetCTypeName (WireType $item)}}){{.Expr}})) {
+    return -1;
+}
+{{CPutFunc (WireType $item) $item.ByteOrder}}(ptr + offset, ({{GetCTypeName (WireType $item)}}){{.Expr}});
+{{- else}}
+{{CPutFunc (WireType $item) $item.ByteOrder}}(ptr + offset, {{.Expr}});
+{{- end}}
+offset += {{PrimitiveSize (WireType $item)}};
+{{- end}}
+{{- end}}
+
+{{- define "deserializeElement"}}
+{{- $item := .Item}}
+{{- if eq $item.Type "container"}}
+{
+    int consumed = {{$item.Container | ToSnakeCase}}_deserialize(&{{.Expr}}, ptr + offset, buffer_size - offset);
+    if (consumed < 0) {
+        return -1;
+    }
+    offset += (size_t)consumed;
+}
+{{- else if eq $item.Type "string"}}
+{{- if eq $item.Encoding "prefixed"}}
+{
+    if (offset + {{PrimitiveSize $item.LengthType}} > buffer_size) {
+        return -1;
+    }
+    size_t str_len = (size_t){{CGetFunc $item.LengthType $item.ByteOrder}}(ptr + offset);
+    offset += {{PrimitiveSize $item.LengthType}};
+    if (str_len > {{$item.MaxLength}} || offset + str_len > buffer_size) {
+        return -1;
+    }
+    memcpy({{.Expr}}, ptr + offset, str_len);
+    {{.Expr}}[str_len] = '\0';
+    offset += str_len;
+}
+{{- else}}
+if (offset + {{$item.MaxLength}} > buffer_size) {
+    return -1;
+}
+memcpy({{.Expr}}, ptr + offset, {{$item.MaxLength}});
+{{.Expr}}[{{$item.MaxLength}}] = '\0';
+offset += {{$item.MaxLength}};
+{{- end}}
+{{- else}}
+if (offset + {{PrimitiveSize (WireType $item)}} > buffer_size) {
+    return -1;
+}
+{{- if eq $item.Type "enum"}}
+{
+    {{GetCTypeName (WireType $item)}} raw = {{CGetFunc (WireType $item) $item.ByteOrder}}(ptr + offset);
+    if (!{{$item.Enum | ToSnakeCase}}_is_valid(raw)) {
+        return -1;
+    }
+    {{.Expr}} = ({{$item.Enum}}_t)raw;
+}
+{{- else}}
+{{.Expr}} = {{CGetFunc (WireType $item) $item.ByteOrder}}(ptr + offset);
+{{- end}}
+offset += {{PrimitiveSize (WireType $item)}};
+{{- end}}
+{{- end}}
 `))
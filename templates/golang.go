@@ -0,0 +1,330 @@
+package templates
+
+import (
+	"text/template"
+)
+
+// GoTemplate generates a Go struct implementing encoding.BinaryMarshaler and
+// encoding.BinaryUnmarshaler. The output is expected to be run through
+// go/format before it is written.
+var GoTemplate = template.Must(withInclude(template.New("golang").Funcs(templateFuncs)).Parse(`// Code generated by uscdl. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+{{- range GoImports .Container}}
+	"{{.}}"
+{{- end}}
+)
+
+// {{GoName .Name}} {{.Description}}
+type {{GoName .Name}} struct {
+{{- range .Items}}
+	// {{.Description}}{{if .Units}} ({{.Units}}){{end}}
+	{{GoName .Name}} {{GetGoFieldType .}} {{GetGoTags .}}
+{{- end}}
+}
+
+const (
+	// {{GoName .Name}}MinSize is the minimum encoded size of {{GoName .Name}} in bytes
+	{{GoName .Name}}MinSize = {{CalculateMinStructSize .Container}}
+	// {{GoName .Name}}Size is the maximum encoded size of {{GoName .Name}} in bytes
+	{{GoName .Name}}Size = {{CalculateStructSize .Container}}
+	// {{GoName .Name}}Fingerprint identifies the wire layout of {{GoName .Name}}; peers
+	// built from a different layout have a different value
+	{{GoName .Name}}Fingerprint uint32 = {{LayoutFingerprint .Container}}
+)
+
+// Check{{GoName .Name}}Fingerprint returns an error when a peer's fingerprint does not
+// match the {{GoName .Name}} wire layout of this build
+func Check{{GoName .Name}}Fingerprint(fingerprint uint32) error {
+	if fingerprint != {{GoName .Name}}Fingerprint {
+		return fmt.Errorf("{{GoName .Name}} layout mismatch: peer fingerprint %#08x, want %#08x", fingerprint, {{GoName .Name}}Fingerprint)
+	}
+	return nil
+}
+
+var (
+	_ encoding.BinaryMarshaler   = (*{{GoName .Name}})(nil)
+	_ encoding.BinaryUnmarshaler = (*{{GoName .Name}})(nil)
+)
+
+// New{{GoName .Name}} returns a {{GoName .Name}} holding default values
+func New{{GoName .Name}}() *{{GoName .Name}} {
+	m := &{{GoName .Name}}{}
+{{- range .Items}}
+{{- if and (or (eq .Type "container") (eq .Type "enum")) (not (IsVariableArray .))}}
+{{- $value := ""}}
+{{- if eq .Type "container"}}{{$value = printf "*New%s()" (GoName .Container)}}{{else}}{{$value = EnumDefaultGo .EnumType}}{{end}}
+{{- if .IsArray}}
+	for i := range m.{{GoName .Name}} {
+		m.{{GoName .Name}}[i] = {{$value}}
+	}
+{{- else}}
+	m.{{GoName .Name}} = {{$value}}
+{{- end}}
+{{- end}}
+{{- end}}
+	return m
+}
+{{- $container := .}}
+{{- range .Items}}
+{{- if eq .Type "bitfield"}}
+{{- $item := .}}
+{{- range .Bits}}
+
+{{- if le .Width 1}}
+
+// {{GoName $item.Name}}{{GoName .Name}} reports whether the {{.Name}} bit of {{$item.Name}} is set
+func (m *{{GoName $container.Name}}) {{GoName $item.Name}}{{GoName .Name}}() bool {
+	return (m.{{GoName $item.Name}}>>{{.Offset}})&0x1 != 0
+}
+
+// Set{{GoName $item.Name}}{{GoName .Name}} sets or clears the {{.Name}} bit of {{$item.Name}}
+func (m *{{GoName $container.Name}}) Set{{GoName $item.Name}}{{GoName .Name}}(v bool) {
+	m.{{GoName $item.Name}} &^= 0x1 << {{.Offset}}
+	if v {
+		m.{{GoName $item.Name}} |= 0x1 << {{.Offset}}
+	}
+}
+{{- else}}
+
+// {{GoName $item.Name}}{{GoName .Name}} returns the {{.Name}} field of {{$item.Name}}
+func (m *{{GoName $container.Name}}) {{GoName $item.Name}}{{GoName .Name}}() {{GetGoType $item}} {
+	return (m.{{GoName $item.Name}} >> {{.Offset}}) & {{printf "0x%X" (BitValueMask .)}}
+}
+
+// Set{{GoName $item.Name}}{{GoName .Name}} stores v in the {{.Name}} field of {{$item.Name}}
+func (m *{{GoName $container.Name}}) Set{{GoName $item.Name}}{{GoName .Name}}(v {{GetGoType $item}}) {
+	m.{{GoName $item.Name}} = m.{{GoName $item.Name}}&^({{printf "0x%X" (BitValueMask .)}}<<{{.Offset}}) | (v&{{printf "0x%X" (BitValueMask .)}})<<{{.Offset}}
+}
+{{- end}}
+{{- end}}
+{{- end}}
+{{- end}}
+
+// MarshalBinary encodes m into a newly allocated buffer
+func (m *{{GoName .Name}}) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, {{GoName .Name}}Size)
+	n, err := m.Encode(buf)
+	if err != nil {
+		return nil, err
+	}
+	return buf[:n], nil
+}
+
+// UnmarshalBinary decodes m from data
+func (m *{{GoName .Name}}) UnmarshalBinary(data []byte) error {
+	_, err := m.Decode(data)
+	return err
+}
+
+// Encode writes m into buf and returns the number of bytes written
+func (m *{{GoName .Name}}) Encode(buf []byte) (int, error) {
+	off := 0
+{{- range .Items}}
+
+	// {{.Name}}
+{{- if IsVariableArray .}}
+	if len(m.{{GoName .Name}}) > {{.MaxLength}} {
+		return 0, fmt.Errorf("{{.Name}}: %d elements exceed the maximum of {{.MaxLength}}", len(m.{{GoName .Name}}))
+	}
+{{- if .LengthField}}
+	if len(m.{{GoName .Name}}) != int(m.{{GoName .LengthField}}) {
+		return 0, fmt.Errorf("{{.Name}}: %d elements but {{.LengthField}} is %d", len(m.{{GoName .Name}}), m.{{GoName .LengthField}})
+	}
+{{- else}}
+	if off+{{PrimitiveSize .LengthType}} > len(buf) {
+		return 0, fmt.Errorf("{{.Name}}: %w", io.ErrShortBuffer)
+	}
+	{{GoPut .LengthType .ByteOrder (printf "%s(len(m.%s))" (GoTypeName .LengthType) (GoName .Name))}}
+	off += {{PrimitiveSize .LengthType}}
+{{- end}}
+{{- end}}
+{{- if .IsArray}}
+	for i := range m.{{GoName .Name}} {
+{{include "goEncodeElement" (Element . (printf "m.%s[i]" (GoName .Name))) | Indent 8}}
+	}
+{{- else}}
+{{include "goEncodeElement" (Element . (printf "m.%s" (GoName .Name))) | Indent 4}}
+{{- end}}
+{{- end}}
+
+	return off, nil
+}
+
+// Decode reads m from data and returns the number of bytes consumed
+func (m *{{GoName .Name}}) Decode(data []byte) (int, error) {
+	off := 0
+{{- range .Items}}
+
+	// {{.Name}}
+{{- if IsVariableArray .}}
+{{- if .LengthField}}
+	{{.Name}}Count := int(m.{{GoName .LengthField}})
+{{- else}}
+	if off+{{PrimitiveSize .LengthType}} > len(data) {
+		return 0, fmt.Errorf("{{.Name}}: %w", io.ErrUnexpectedEOF)
+	}
+	{{.Name}}Count := int({{GoGet .LengthType .ByteOrder}})
+	off += {{PrimitiveSize .LengthType}}
+{{- end}}
+	if {{.Name}}Count > {{.MaxLength}} {
+		return 0, fmt.Errorf("{{.Name}}: %d elements exceed the maximum of {{.MaxLength}}", {{.Name}}Count)
+	}
+	m.{{GoName .Name}} = make({{GetGoFieldType .}}, {{.Name}}Count)
+{{- end}}
+{{- if .IsArray}}
+	for i := range m.{{GoName .Name}} {
+{{include "goDecodeElement" (Element . (printf "m.%s[i]" (GoName .Name))) | Indent 8}}
+	}
+{{- else}}
+{{include "goDecodeElement" (Element . (printf "m.%s" (GoName .Name))) | Indent 4}}
+{{- end}}
+{{- end}}
+
+	return off, nil
+}
+
+{{- define "goEncodeElement"}}
+{{- $item := .Item}}
+{{- if eq $item.Type "container"}}
+{
+    n, err := {{.Expr}}.Encode(buf[off:])
+    if err != nil {
+        return 0, fmt.Errorf("{{$item.Name}}: %w", err)
+    }
+    off += n
+}
+{{- else if eq $item.Type "string"}}
+if len({{.Expr}}) > {{$item.MaxLength}} {
+    return 0, fmt.Errorf("{{$item.Name}}: length %d exceeds the maximum of {{$item.MaxLength}}", len({{.Expr}}))
+}
+{{- if eq $item.Encoding "prefixed"}}
+if off+{{PrimitiveSize $item.LengthType}}+len({{.Expr}}) > len(buf) {
+    return 0, fmt.Errorf("{{$item.Name}}: %w", io.ErrShortBuffer)
+}
+{{GoPut $item.LengthType $item.ByteOrder (printf "%s(len(%s))" (GoTypeName $item.LengthType) .Expr)}}
+off += {{PrimitiveSize $item.LengthType}}
+off += copy(buf[off:], {{.Expr}})
+{{- else}}
+if off+{{$item.MaxLength}} > len(buf) {
+    return 0, fmt.Errorf("{{$item.Name}}: %w", io.ErrShortBuffer)
+}
+{
+    n := copy(buf[off:off+{{$item.MaxLength}}], {{.Expr}})
+    clear(buf[off+n : off+{{$item.MaxLength}}])
+}
+off += {{$item.MaxLength}}
+{{- end}}
+{{- else}}
+if off+{{PrimitiveSize (WireType $item)}} > len(buf) {
+    return 0, fmt.Errorf("{{$item.Name}}: %w", io.ErrShortBuffer)
+}
+{{- if eq $item.Type "enum"}}
+if !{{.Expr}}.IsValid() {
+    return 0, fmt.Errorf("{{$item.Name}}: invalid {{GoName $item.Enum}} %d", {{.Expr}})
+}
+{{GoPut (WireType $item) $item.ByteOrder (printf "%s(%s)" (GoTypeName (WireType $item)) .Expr)}}
+{{- else}}
+{{GoPut (WireType $item) $item.ByteOrder .Expr}}
+{{- end}}
+off += {{PrimitiveSize (WireType $item)}}
+{{- end}}
+{{- end}}
+
+{{- define "goDecodeElement"}}
+{{- $item := .Item}}
+{{- if eq $item.Type "container"}}
+{
+    n, err := {{.Expr}}.Decode(data[off:])
+    if err != nil {
+        return 0, fmt.Errorf("{{$item.Name}}: %w", err)
+    }
+    off += n
+}
+{{- else if eq $item.Type "string"}}
+{{- if eq $item.Encoding "prefixed"}}
+{
+    if off+{{PrimitiveSize $item.LengthType}} > len(data) {
+        return 0, fmt.Errorf("{{$item.Name}}: %w", io.ErrUnexpectedEOF)
+    }
+    n := int({{GoGet $item.LengthType $item.ByteOrder}})
+    off += {{PrimitiveSize $item.LengthType}}
+    if n > {{$item.MaxLength}} {
+        return 0, fmt.Errorf("{{$item.Name}}: length %d exceeds the maximum of {{$item.MaxLength}}", n)
+    }
+    if off+n > len(data) {
+        return 0, fmt.Errorf("{{$item.Name}}: %w", io.ErrUnexpectedEOF)
+    }
+    {{.Expr}} = string(data[off : off+n])
+    off += n
+}
+{{- else}}
+if off+{{$item.MaxLength}} > len(data) {
+    return 0, fmt.Errorf("{{$item.Name}}: %w", io.ErrUnexpectedEOF)
+}
+{
+    field := data[off : off+{{$item.MaxLength}}]
+    if end := bytes.IndexByte(field, 0); end >= 0 {
+        field = field[:end]
+    }
+    {{.Expr}} = string(field)
+}
+off += {{$item.MaxLength}}
+{{- end}}
+{{- else}}
+if off+{{PrimitiveSize (WireType $item)}} > len(data) {
+    return 0, fmt.Errorf("{{$item.Name}}: %w", io.ErrUnexpectedEOF)
+}
+{{- if eq $item.Type "enum"}}
+{{.Expr}} = {{GoName $item.Enum}}({{GoGet (WireType $item) $item.ByteOrder}})
+if !{{.Expr}}.IsValid() {
+    return 0, fmt.Errorf("{{$item.Name}}: invalid {{GoName $item.Enum}} %d", {{.Expr}})
+}
+{{- else}}
+{{.Expr}} = {{GoGet (WireType $item) $item.ByteOrder}}
+{{- end}}
+off += {{PrimitiveSize (WireType $item)}}
+{{- end}}
+{{- end}}
+`))
+
+// GoEnumTemplate generates a Go named integer type with constants for each
+// enumeration value
+var GoEnumTemplate = template.Must(template.New("goenum").Funcs(templateFuncs).Parse(`// Code generated by uscdl. DO NOT EDIT.
+
+package {{.Package}}
+
+import "fmt"
+
+// {{GoName .Name}} {{.Description}}
+type {{GoName .Name}} {{GoTypeName .Type}}
+
+const (
+{{- range .Values}}
+	// {{GoName $.Name}}{{GoName .Name}} {{.Description}}
+	{{GoName $.Name}}{{GoName .Name}} {{GoName $.Name}} = {{.Value}}
+{{- end}}
+)
+
+// IsValid reports whether v names a {{GoName .Name}} member
+func (v {{GoName .Name}}) IsValid() bool {
+	switch v {
+	case {{range $i, $value := .Values}}{{if $i}}, {{end}}{{GoName $.Name}}{{GoName $value.Name}}{{end}}:
+		return true
+	}
+	return false
+}
+
+// String returns the name of v
+func (v {{GoName .Name}}) String() string {
+	switch v {
+{{- range .Values}}
+	case {{GoName $.Name}}{{GoName .Name}}:
+		return "{{.Name}}"
+{{- end}}
+	}
+	return fmt.Sprintf("{{GoName .Name}}(%d)", {{GoTypeName .Type}}(v))
+}
+`))
@@ -2,28 +2,27 @@ package templates
 
 import (
 	"fmt"
+	"math"
 	"strings"
 
 	"github.com/iancoleman/strcase"
+	"github.com/sammyjroberts/uscdl/model"
 )
 
-// Item represents a property within a container
-type Item struct {
-	Name        string
-	Type        string
-	Description string
-	ByteOrder   string
-	Units       string
-	IsArray     bool
-	Length      int
-}
-
-// Container represents a struct that contains multiple items
-type Container struct {
-	Name        string
-	Description string
-	Items       []Item
-}
+// The templates render the config model directly; items reach the enums and
+// containers they reference through EnumType and ContainerType.
+type (
+	// Item represents a property within a container
+	Item = model.Item
+	// Bit represents a named bit or multi-bit sub-field of a bitfield item
+	Bit = model.Bit
+	// Container represents a struct that contains multiple items
+	Container = model.Container
+	// EnumValue represents a single named value of an enumeration
+	EnumValue = model.EnumValue
+	// Enum represents a named enumeration carried on the wire as an integer type
+	Enum = model.Enum
+)
 
 // CTypeMapping maps JSON types to C types
 var CTypeMapping = map[string]string{
@@ -38,7 +37,7 @@ var CTypeMapping = map[string]string{
 	"float":  "float",
 	"double": "double",
 	"bool":   "bool",
-	"string": "char*",
+	"string": "char",
 }
 
 // TSTypeMapping maps JSON types to TypeScript types
@@ -46,11 +45,11 @@ var TSTypeMapping = map[string]string{
 	"uint8":  "number",
 	"uint16": "number",
 	"uint32": "number",
-	"uint64": "number",
+	"uint64": "bigint",
 	"int8":   "number",
 	"int16":  "number",
 	"int32":  "number",
-	"int64":  "number",
+	"int64":  "bigint",
 	"float":  "number",
 	"double": "number",
 	"bool":   "boolean",
@@ -59,7 +58,18 @@ var TSTypeMapping = map[string]string{
 
 // GetCType returns the C type for a given item
 func GetCType(item Item) string {
-	cType, ok := CTypeMapping[item.Type]
+	if item.Type == "enum" && item.EnumType != nil {
+		return item.EnumType.Name + "_t"
+	}
+	if item.Type == "container" && item.ContainerType != nil {
+		return item.ContainerType.Name + "_t"
+	}
+	return GetCTypeName(WireType(item))
+}
+
+// GetCTypeName returns the C type for a primitive type name
+func GetCTypeName(itemType string) string {
+	cType, ok := CTypeMapping[itemType]
 	if !ok {
 		return "void"
 	}
@@ -69,6 +79,19 @@ func GetCType(item Item) string {
 // GetTSType returns the TypeScript type for a given item
 func GetTSType(item Item) string {
 	tsType, ok := TSTypeMapping[item.Type]
+	if item.Type == "enum" && item.EnumType != nil {
+		tsType, ok = item.EnumType.Name, true
+	}
+	if item.Type == "container" && item.ContainerType != nil {
+		tsType, ok = item.ContainerType.Name, true
+	}
+	if item.Type == "bitfield" {
+		fields := make([]string, len(item.Bits))
+		for i, bit := range item.Bits {
+			fields[i] = fmt.Sprintf("%s: %s", bit.Name, GetBitTSType(bit))
+		}
+		tsType, ok = fmt.Sprintf("{ %s }", strings.Join(fields, "; ")), true
+	}
 	if !ok {
 		return "any"
 	}
@@ -89,8 +112,6 @@ func GetDefaultValueC(itemType string) string {
 		return "0.0"
 	case "bool":
 		return "false"
-	case "string":
-		return "NULL"
 	default:
 		return "0"
 	}
@@ -98,10 +119,43 @@ func GetDefaultValueC(itemType string) string {
 
 // GetDefaultValueTS returns the default value for a TypeScript type and item
 func GetDefaultValueTS(item Item) string {
+	if item.Type == "enum" && item.EnumType != nil {
+		value := EnumDefaultTS(item.EnumType)
+		if item.IsArray {
+			return fmt.Sprintf("Array(%d).fill(%s)", item.Length, value)
+		}
+		return value
+	}
+
+	if IsVariableArray(item) {
+		return "[]"
+	}
+
+	if item.Type == "container" && item.ContainerType != nil {
+		if item.IsArray {
+			return fmt.Sprintf("Array.from({ length: %d }, () => create%s())", item.Length, item.ContainerType.Name)
+		}
+		return fmt.Sprintf("create%s()", item.ContainerType.Name)
+	}
+
+	if item.Type == "bitfield" {
+		fields := make([]string, len(item.Bits))
+		for i, bit := range item.Bits {
+			value := "0"
+			if bit.Width <= 1 {
+				value = "false"
+			}
+			fields[i] = fmt.Sprintf("%s: %s", bit.Name, value)
+		}
+		return fmt.Sprintf("{ %s }", strings.Join(fields, ", "))
+	}
+
 	if item.IsArray {
 		switch item.Type {
-		case "uint8", "uint16", "uint32", "uint64", "int8", "int16", "int32", "int64", "float", "double":
+		case "uint8", "uint16", "uint32", "int8", "int16", "int32", "float", "double":
 			return fmt.Sprintf("Array(%d).fill(0)", item.Length)
+		case "uint64", "int64":
+			return fmt.Sprintf("Array(%d).fill(0n)", item.Length)
 		case "bool":
 			return fmt.Sprintf("Array(%d).fill(false)", item.Length)
 		case "string":
@@ -112,8 +166,10 @@ func GetDefaultValueTS(item Item) string {
 	}
 
 	switch item.Type {
-	case "uint8", "uint16", "uint32", "uint64", "int8", "int16", "int32", "int64", "float", "double":
+	case "uint8", "uint16", "uint32", "int8", "int16", "int32", "float", "double":
 		return "0"
+	case "uint64", "int64":
+		return "0n"
 	case "bool":
 		return "false"
 	case "string":
@@ -134,59 +190,873 @@ func GetTypeSizeC(itemType string) string {
 		return "4"
 	case "uint64", "int64", "double":
 		return "8"
-	case "string":
-		return "sizeof(char*)"
 	default:
 		return "1"
 	}
 }
 
-// NeedsByteSwap determines if a type needs byte swapping based on endianness
-func NeedsByteSwap(item Item) bool {
-	if item.ByteOrder == "big" {
-		return true
+// CalculateStructSize calculates the maximum size of a struct in bytes
+func CalculateStructSize(container Container) string {
+	return fmt.Sprintf("%d", StructSize(container))
+}
+
+// CalculateMinStructSize calculates the minimum size of a struct in bytes
+func CalculateMinStructSize(container Container) string {
+	return fmt.Sprintf("%d", MinStructSize(container))
+}
+
+// StructSize returns the maximum serialized size of a container in bytes,
+// including any nested containers
+func StructSize(container Container) int {
+	size := 0
+	for _, item := range container.Items {
+		size += ItemSize(item)
 	}
-	return false
+	return size
 }
 
-// CalculateStructSize calculates the approximate size of a struct in bytes
-func CalculateStructSize(container Container) string {
+// MinStructSize returns the minimum serialized size of a container in bytes,
+// with every variable-length item empty
+func MinStructSize(container Container) int {
 	size := 0
 	for _, item := range container.Items {
-		var itemSize int
-		switch item.Type {
-		case "uint8", "int8", "bool":
-			itemSize = 1
-		case "uint16", "int16":
-			itemSize = 2
-		case "uint32", "int32", "float":
-			itemSize = 4
-		case "uint64", "int64", "double":
-			itemSize = 8
-		case "string":
-			itemSize = 4 // Pointer size on 32-bit systems
+		size += ItemMinSize(item)
+	}
+	return size
+}
+
+// ItemSize returns the maximum serialized size of an item in bytes
+func ItemSize(item Item) int {
+	return itemSize(item, false)
+}
+
+// ItemMinSize returns the minimum serialized size of an item in bytes
+func ItemMinSize(item Item) int {
+	return itemSize(item, true)
+}
+
+func itemSize(item Item, min bool) int {
+	var size int
+	switch WireType(item) {
+	case "string":
+		size = item.MaxLength
+		if item.Encoding == "prefixed" {
+			size = PrimitiveSize(item.LengthType)
+			if !min {
+				size += item.MaxLength
+			}
+		}
+	case "container":
+		if item.ContainerType != nil {
+			size = StructSize(*item.ContainerType)
+			if min {
+				size = MinStructSize(*item.ContainerType)
+			}
 		}
+	default:
+		size = PrimitiveSize(WireType(item))
+	}
 
-		if item.IsArray {
-			itemSize *= item.Length
+	if !item.IsArray {
+		return size
+	}
+	if !IsVariableArray(item) {
+		return size * item.Length
+	}
+
+	prefix := 0
+	if item.LengthField == "" {
+		prefix = PrimitiveSize(item.LengthType)
+	}
+	if min {
+		return prefix
+	}
+	return prefix + size*item.MaxLength
+}
+
+// LayoutFingerprint returns the wire layout fingerprint of a container as a
+// hexadecimal literal
+func LayoutFingerprint(container Container) string {
+	return fmt.Sprintf("0x%08X", container.Fingerprint())
+}
+
+// PrimitiveSize returns the size in bytes of a primitive type
+func PrimitiveSize(itemType string) int {
+	return model.PrimitiveSize(itemType)
+}
+
+// WireType returns the primitive type an item is encoded as on the wire
+func WireType(item Item) string {
+	if item.Type == "enum" && item.EnumType != nil {
+		return item.EnumType.Type
+	}
+	if item.Type == "bitfield" {
+		return item.BaseType
+	}
+	return item.Type
+}
+
+// GetDataViewType returns the DataView accessor suffix for a primitive type
+func GetDataViewType(itemType string) string {
+	switch itemType {
+	case "uint8":
+		return "Uint8"
+	case "uint16":
+		return "Uint16"
+	case "uint32":
+		return "Uint32"
+	case "int8":
+		return "Int8"
+	case "int16":
+		return "Int16"
+	case "int32":
+		return "Int32"
+	case "uint64":
+		return "BigUint64"
+	case "int64":
+		return "BigInt64"
+	case "float":
+		return "Float32"
+	case "double":
+		return "Float64"
+	default:
+		return "Uint8"
+	}
+}
+
+// GetTSLittleEndianArg returns the DataView littleEndian argument for an item,
+// or an empty string for single-byte types which take no such argument
+func GetTSLittleEndianArg(item Item) string {
+	return tsLittleEndianArg(WireType(item), item.ByteOrder)
+}
+
+// GetTSLengthEndianArg returns the DataView littleEndian argument for the
+// length prefix of an item
+func GetTSLengthEndianArg(item Item) string {
+	return tsLittleEndianArg(item.LengthType, item.ByteOrder)
+}
+
+func tsLittleEndianArg(itemType, byteOrder string) string {
+	switch itemType {
+	case "uint8", "int8", "bool":
+		return ""
+	}
+	if byteOrder == "big" {
+		return ", false"
+	}
+	return ", true"
+}
+
+// EnumConstantC returns the C enumerator name for a value of an enumeration
+func EnumConstantC(enum *Enum, value EnumValue) string {
+	return strings.ToUpper(ToSnakeCase(enum.Name) + "_" + ToSnakeCase(value.Name))
+}
+
+// EnumFitsCInt reports whether every value of an enumeration fits a C int,
+// which ISO C requires of enumerators. Enumerations that do not are emitted
+// as an integer typedef with a #define per value.
+func EnumFitsCInt(enum *Enum) bool {
+	for _, value := range enum.Values {
+		if value.Value < math.MinInt32 || value.Value > math.MaxInt32 {
+			return false
+		}
+	}
+	return true
+}
+
+// EnumDefaultC returns the C enumerator used to initialize an enum item
+func EnumDefaultC(enum *Enum) string {
+	if len(enum.Values) == 0 {
+		return "0"
+	}
+	return EnumConstantC(enum, enum.Values[0])
+}
+
+// EnumDefaultGo returns the Go constant used to initialize an enum item
+func EnumDefaultGo(enum *Enum) string {
+	if len(enum.Values) == 0 {
+		return "0"
+	}
+	return GoName(enum.Name) + GoName(enum.Values[0].Name)
+}
+
+// EnumDefaultTS returns the TypeScript enum member used to initialize an enum item
+func EnumDefaultTS(enum *Enum) string {
+	if len(enum.Values) == 0 {
+		return "0"
+	}
+	return fmt.Sprintf("%s.%s", enum.Name, enum.Values[0].Name)
+}
+
+// UsedEnums returns the distinct enumerations referenced by a container's items
+func UsedEnums(container Container) []*Enum {
+	var enums []*Enum
+	seen := make(map[string]bool)
+	for _, item := range container.Items {
+		if item.Type != "enum" || item.EnumType == nil || seen[item.EnumType.Name] {
+			continue
 		}
+		seen[item.EnumType.Name] = true
+		enums = append(enums, item.EnumType)
+	}
+	return enums
+}
+
+// BitMacroName returns the C macro prefix for a bit of a bitfield item
+func BitMacroName(containerName, itemName, bitName string) string {
+	return strings.ToUpper(ToSnakeCase(containerName) + "_" + ToSnakeCase(itemName) + "_" + ToSnakeCase(bitName))
+}
+
+// BitMask returns the mask of a bit within its underlying integer
+func BitMask(bit Bit) string {
+	return fmt.Sprintf("0x%Xu", BitValueMask(bit)<<uint(bit.Offset))
+}
+
+// BitValueMask returns the unshifted mask covering the width of a bit
+func BitValueMask(bit Bit) uint64 {
+	width := bit.Width
+	if width < 1 {
+		width = 1
+	}
+	return (uint64(1) << uint(width)) - 1
+}
+
+// BitNeedsRangeCheck reports whether a multi-bit field is narrower than the
+// base type, so that a value of the base type may not fit it
+func BitNeedsRangeCheck(item Item, bit Bit) bool {
+	return bit.Width < 8*PrimitiveSize(item.BaseType)
+}
+
+// GetBitCType returns the C type used by the accessors of a bit
+func GetBitCType(item Item, bit Bit) string {
+	if bit.Width <= 1 {
+		return "bool"
+	}
+	return GetCTypeName(item.BaseType)
+}
+
+// GetBitTSType returns the TypeScript type of a decoded bit
+func GetBitTSType(bit Bit) string {
+	if bit.Width <= 1 {
+		return "boolean"
+	}
+	return "number"
+}
 
-		size += itemSize
+// IsVariableArray reports whether an item is an array bounded by maxLength
+// whose element count travels on the wire
+func IsVariableArray(item Item) bool {
+	return item.IsArray && item.MaxLength > 0
+}
+
+// ArrayCapacity returns the number of elements reserved for an array item
+func ArrayCapacity(item Item) int {
+	if IsVariableArray(item) {
+		return item.MaxLength
+	}
+	return item.Length
+}
+
+// ArrayBoundC returns the C expression for the number of elements to
+// serialize in an array item
+func ArrayBoundC(item Item) string {
+	if IsVariableArray(item) {
+		return item.Name + "_count"
 	}
-	return fmt.Sprintf("%d", size)
+	return fmt.Sprintf("%d", item.Length)
 }
 
-// ByteOrderFunctionsNeeded checks if any items in the container need byte swapping
-func ByteOrderFunctionsNeeded(container Container) bool {
+// ArrayBoundTS returns the TypeScript expression for the number of elements to
+// serialize in an array item
+func ArrayBoundTS(item Item) string {
+	if IsVariableArray(item) {
+		return item.Name + "Count"
+	}
+	return fmt.Sprintf("%d", item.Length)
+}
+
+// ArrayCountMemberC returns the struct member holding the element count of a
+// variable-length array item
+func ArrayCountMemberC(item Item) string {
+	if item.LengthField != "" {
+		return item.LengthField
+	}
+	return item.Name + "Count"
+}
+
+// UsedContainers returns the distinct containers nested in a container's items
+func UsedContainers(container Container) []*Container {
+	var containers []*Container
+	seen := make(map[string]bool)
 	for _, item := range container.Items {
-		if item.ByteOrder == "big" &&
-			(item.Type == "uint16" || item.Type == "uint32" || item.Type == "uint64" ||
-				item.Type == "int16" || item.Type == "int32" || item.Type == "int64" ||
-				item.Type == "float" || item.Type == "double") {
-			return true
+		if item.Type != "container" || item.ContainerType == nil || seen[item.ContainerType.Name] {
+			continue
+		}
+		seen[item.ContainerType.Name] = true
+		containers = append(containers, item.ContainerType)
+	}
+	return containers
+}
+
+// ElementRef pairs an item with the C expression of one of its elements, so a
+// single sub-template can encode both scalars and array elements
+type ElementRef struct {
+	Item Item
+	Expr string
+}
+
+// Element returns an ElementRef for an item and element expression
+func Element(item Item, expr string) ElementRef {
+	return ElementRef{Item: item, Expr: expr}
+}
+
+// CEndianSuffix returns the uscdl_endian.h function suffix for a primitive type
+// in a byte order, e.g. "u16_be" or "f32_le". Single-byte types carry no order.
+func CEndianSuffix(itemType, byteOrder string) string {
+	order := "le"
+	if byteOrder == "big" {
+		order = "be"
+	}
+	switch itemType {
+	case "uint8":
+		return "u8"
+	case "int8":
+		return "i8"
+	case "bool":
+		return "bool"
+	case "uint16":
+		return "u16_" + order
+	case "uint32":
+		return "u32_" + order
+	case "uint64":
+		return "u64_" + order
+	case "int16":
+		return "i16_" + order
+	case "int32":
+		return "i32_" + order
+	case "int64":
+		return "i64_" + order
+	case "float":
+		return "f32_" + order
+	case "double":
+		return "f64_" + order
+	default:
+		return "u8"
+	}
+}
+
+// CPutFunc returns the uscdl_endian.h writer for a primitive type in a byte order
+func CPutFunc(itemType, byteOrder string) string {
+	return "uscdl_put_" + CEndianSuffix(itemType, byteOrder)
+}
+
+// CGetFunc returns the uscdl_endian.h reader for a primitive type in a byte order
+func CGetFunc(itemType, byteOrder string) string {
+	return "uscdl_get_" + CEndianSuffix(itemType, byteOrder)
+}
+
+// Indent prefixes every non-empty line of s with the given number of spaces
+func Indent(spaces int, s string) string {
+	prefix := strings.Repeat(" ", spaces)
+	lines := strings.Split(strings.Trim(s, "\n"), "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = prefix + line
 		}
 	}
-	return false
+	return strings.Join(lines, "\n")
+}
+
+// GoFile is the data passed to the Go container template
+type GoFile struct {
+	Package string
+	Container
+}
+
+// GoEnumFile is the data passed to the Go enum template
+type GoEnumFile struct {
+	Package string
+	*Enum
+}
+
+// Module is the data passed to templates that emit every enum and container
+// of a config into one file
+type Module struct {
+	Name       string
+	Enums      []*Enum
+	Containers []*Container
+}
+
+// NewModule returns the module data for every enum and container of a config,
+// in config order
+func NewModule(name string, config *model.Config) Module {
+	module := Module{Name: name}
+	for i := range config.Enums {
+		module.Enums = append(module.Enums, &config.Enums[i])
+	}
+	for i := range config.Containers {
+		module.Containers = append(module.Containers, &config.Containers[i])
+	}
+	return module
+}
+
+// GoTypeMapping maps JSON types to Go types
+var GoTypeMapping = map[string]string{
+	"uint8":  "uint8",
+	"uint16": "uint16",
+	"uint32": "uint32",
+	"uint64": "uint64",
+	"int8":   "int8",
+	"int16":  "int16",
+	"int32":  "int32",
+	"int64":  "int64",
+	"float":  "float32",
+	"double": "float64",
+	"bool":   "bool",
+	"string": "string",
+}
+
+// GoName converts a schema name to an exported Go identifier. camelCase and
+// PascalCase names keep their acronyms; separated names are camel-cased.
+func GoName(name string) string {
+	if name == "" || strings.ContainsAny(name, "_- ") {
+		return strcase.ToCamel(name)
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// GoTypeName returns the Go type of a primitive JSON type
+func GoTypeName(itemType string) string {
+	return GoTypeMapping[itemType]
+}
+
+// GetGoType returns the Go type of a single element of an item
+func GetGoType(item Item) string {
+	if item.Type == "enum" && item.EnumType != nil {
+		return GoName(item.EnumType.Name)
+	}
+	if item.Type == "container" && item.ContainerType != nil {
+		return GoName(item.ContainerType.Name)
+	}
+	return GoTypeName(WireType(item))
+}
+
+// GetGoFieldType returns the Go type of the struct field holding an item
+func GetGoFieldType(item Item) string {
+	if IsVariableArray(item) {
+		return "[]" + GetGoType(item)
+	}
+	if item.IsArray {
+		return fmt.Sprintf("[%d]%s", item.Length, GetGoType(item))
+	}
+	return GetGoType(item)
+}
+
+// GetGoTags returns the struct tags of the field holding an item
+func GetGoTags(item Item) string {
+	if item.Units != "" {
+		return fmt.Sprintf("`json:%q units:%q`", item.Name, item.Units)
+	}
+	return fmt.Sprintf("`json:%q`", item.Name)
+}
+
+// goByteOrder returns the encoding/binary byte order for an item byte order
+func goByteOrder(byteOrder string) string {
+	if byteOrder == "big" {
+		return "binary.BigEndian"
+	}
+	return "binary.LittleEndian"
+}
+
+// GoPut returns a Go statement writing expr as a primitive type at buf[off:]
+func GoPut(itemType, byteOrder, expr string) string {
+	order := goByteOrder(byteOrder)
+	switch itemType {
+	case "uint8":
+		return fmt.Sprintf("buf[off] = %s", expr)
+	case "int8":
+		return fmt.Sprintf("buf[off] = byte(%s)", expr)
+	case "bool":
+		return fmt.Sprintf("buf[off] = 0\nif %s {\n\tbuf[off] = 1\n}", expr)
+	case "uint16", "uint32", "uint64":
+		return fmt.Sprintf("%s.PutUint%d(buf[off:], %s)", order, PrimitiveSize(itemType)*8, expr)
+	case "int16", "int32", "int64":
+		bits := PrimitiveSize(itemType) * 8
+		return fmt.Sprintf("%s.PutUint%d(buf[off:], uint%d(%s))", order, bits, bits, expr)
+	case "float":
+		return fmt.Sprintf("%s.PutUint32(buf[off:], math.Float32bits(%s))", order, expr)
+	case "double":
+		return fmt.Sprintf("%s.PutUint64(buf[off:], math.Float64bits(%s))", order, expr)
+	default:
+		return ""
+	}
+}
+
+// GoGet returns a Go expression reading a primitive type from data[off:]
+func GoGet(itemType, byteOrder string) string {
+	order := goByteOrder(byteOrder)
+	switch itemType {
+	case "uint8":
+		return "data[off]"
+	case "int8":
+		return "int8(data[off])"
+	case "bool":
+		return "data[off] != 0"
+	case "uint16", "uint32", "uint64":
+		return fmt.Sprintf("%s.Uint%d(data[off:])", order, PrimitiveSize(itemType)*8)
+	case "int16", "int32", "int64":
+		bits := PrimitiveSize(itemType) * 8
+		return fmt.Sprintf("int%d(%s.Uint%d(data[off:]))", bits, order, bits)
+	case "float":
+		return fmt.Sprintf("math.Float32frombits(%s.Uint32(data[off:]))", order)
+	case "double":
+		return fmt.Sprintf("math.Float64frombits(%s.Uint64(data[off:]))", order)
+	default:
+		return ""
+	}
+}
+
+// GoImports returns the packages imported by the Go file of a container
+func GoImports(container Container) []string {
+	needed := map[string]bool{"encoding": true, "fmt": true}
+	for _, item := range container.Items {
+		wireType := WireType(item)
+		if wireType != "container" || (IsVariableArray(item) && item.LengthField == "") {
+			needed["io"] = true
+		}
+		if PrimitiveSize(wireType) > 1 {
+			needed["encoding/binary"] = true
+		}
+		if wireType == "float" || wireType == "double" {
+			needed["math"] = true
+		}
+		if wireType == "string" && item.Encoding != "prefixed" {
+			needed["bytes"] = true
+		}
+		if (wireType == "string" && item.Encoding == "prefixed") || (IsVariableArray(item) && item.LengthField == "") {
+			if PrimitiveSize(item.LengthType) > 1 {
+				needed["encoding/binary"] = true
+			}
+		}
+	}
+
+	var imports []string
+	for _, pkg := range []string{"bytes", "encoding", "encoding/binary", "fmt", "io", "math"} {
+		if needed[pkg] {
+			imports = append(imports, pkg)
+		}
+	}
+	return imports
+}
+
+// PyStructFormatMapping maps JSON types to struct module format characters
+var PyStructFormatMapping = map[string]string{
+	"uint8":  "B",
+	"uint16": "H",
+	"uint32": "I",
+	"uint64": "Q",
+	"int8":   "b",
+	"int16":  "h",
+	"int32":  "i",
+	"int64":  "q",
+	"float":  "f",
+	"double": "d",
+	"bool":   "?",
+}
+
+// pyKeywords lists the Python keywords that cannot be used as identifiers
+var pyKeywords = map[string]bool{
+	"False": true, "None": true, "True": true, "and": true, "as": true,
+	"assert": true, "async": true, "await": true, "break": true, "class": true,
+	"continue": true, "def": true, "del": true, "elif": true, "else": true,
+	"except": true, "finally": true, "for": true, "from": true, "global": true,
+	"if": true, "import": true, "in": true, "is": true, "lambda": true,
+	"nonlocal": true, "not": true, "or": true, "pass": true, "raise": true,
+	"return": true, "try": true, "while": true, "with": true, "yield": true,
+}
+
+// PyName returns a Python identifier for a name, appending an underscore to
+// Python keywords
+func PyName(name string) string {
+	if pyKeywords[name] {
+		return name + "_"
+	}
+	return name
+}
+
+// PyFieldName returns the snake_case Python attribute name of an item
+func PyFieldName(name string) string {
+	return PyName(ToSnakeCase(name))
+}
+
+// PyStructFormat returns the struct module format string for a primitive type
+func PyStructFormat(itemType, byteOrder string) string {
+	if byteOrder == "big" {
+		return ">" + PyStructFormatMapping[itemType]
+	}
+	return "<" + PyStructFormatMapping[itemType]
+}
+
+// GetPyType returns the Python type hint of a single element of an item
+func GetPyType(item Item) string {
+	if item.Type == "enum" && item.EnumType != nil {
+		return item.EnumType.Name
+	}
+	if item.Type == "container" && item.ContainerType != nil {
+		return item.ContainerType.Name
+	}
+	switch WireType(item) {
+	case "float", "double":
+		return "float"
+	case "bool":
+		return "bool"
+	case "string":
+		return "str"
+	default:
+		return "int"
+	}
+}
+
+// GetPyFieldType returns the Python type hint of the field holding an item
+func GetPyFieldType(item Item) string {
+	if item.IsArray {
+		return fmt.Sprintf("List[%s]", GetPyType(item))
+	}
+	return GetPyType(item)
+}
+
+// GetDefaultValuePy returns the dataclass field() default argument of an item
+func GetDefaultValuePy(item Item) string {
+	var value string
+	switch {
+	case item.Type == "container" && item.ContainerType != nil:
+		value = item.ContainerType.Name + "()"
+	case item.Type == "enum" && item.EnumType != nil:
+		value = EnumDefaultPy(item.EnumType)
+	default:
+		switch WireType(item) {
+		case "float", "double":
+			value = "0.0"
+		case "bool":
+			value = "False"
+		case "string":
+			value = `""`
+		default:
+			value = "0"
+		}
+	}
+
+	if IsVariableArray(item) {
+		return "default_factory=list"
+	}
+	if item.IsArray {
+		if item.Type == "container" {
+			return fmt.Sprintf("default_factory=lambda: [%s for _ in range(%d)]", value, item.Length)
+		}
+		return fmt.Sprintf("default_factory=lambda: [%s] * %d", value, item.Length)
+	}
+	if item.Type == "container" {
+		return fmt.Sprintf("default_factory=lambda: %s", value)
+	}
+	return fmt.Sprintf("default=%s", value)
+}
+
+// EnumDefaultPy returns the Python enum member used to initialize an enum item
+func EnumDefaultPy(enum *Enum) string {
+	if len(enum.Values) == 0 {
+		return enum.Name + "(0)"
+	}
+	return enum.Name + "." + PyName(enum.Values[0].Name)
+}
+
+// RustTypeMapping maps JSON types to Rust types
+var RustTypeMapping = map[string]string{
+	"uint8":  "u8",
+	"uint16": "u16",
+	"uint32": "u32",
+	"uint64": "u64",
+	"int8":   "i8",
+	"int16":  "i16",
+	"int32":  "i32",
+	"int64":  "i64",
+	"float":  "f32",
+	"double": "f64",
+	"bool":   "bool",
+}
+
+// rustKeywords lists the Rust strict and reserved keywords
+var rustKeywords = map[string]bool{
+	"as": true, "break": true, "const": true, "continue": true, "crate": true,
+	"else": true, "enum": true, "extern": true, "false": true, "fn": true,
+	"for": true, "if": true, "impl": true, "in": true, "let": true,
+	"loop": true, "match": true, "mod": true, "move": true, "mut": true,
+	"pub": true, "ref": true, "return": true, "self": true, "Self": true,
+	"static": true, "struct": true, "super": true, "trait": true, "true": true,
+	"type": true, "unsafe": true, "use": true, "where": true, "while": true,
+	"async": true, "await": true, "dyn": true, "abstract": true, "become": true,
+	"box": true, "do": true, "final": true, "macro": true, "override": true,
+	"priv": true, "typeof": true, "unsized": true, "virtual": true, "yield": true,
+	"try": true, "gen": true,
+}
+
+// RustName returns a Rust identifier for a name, appending an underscore to
+// Rust keywords
+func RustName(name string) string {
+	if rustKeywords[name] {
+		return name + "_"
+	}
+	return name
+}
+
+// RustFieldName returns the snake_case Rust field name of an item
+func RustFieldName(name string) string {
+	return RustName(ToSnakeCase(name))
+}
+
+// RustTypeName returns the Rust type of a primitive JSON type
+func RustTypeName(itemType string) string {
+	return RustTypeMapping[itemType]
+}
+
+// GetRustType returns the Rust type of a single element of an item
+func GetRustType(item Item) string {
+	if item.Type == "enum" && item.EnumType != nil {
+		return item.EnumType.Name
+	}
+	if item.Type == "container" && item.ContainerType != nil {
+		return item.ContainerType.Name
+	}
+	return RustTypeName(WireType(item))
+}
+
+// GetRustFieldType returns the Rust type of the struct field holding an item.
+// Strings and variable-length arrays are stored in fixed-capacity arrays.
+func GetRustFieldType(item Item) string {
+	if item.Type == "string" {
+		return fmt.Sprintf("[u8; %d]", item.MaxLength)
+	}
+	if item.IsArray {
+		return fmt.Sprintf("[%s; %d]", GetRustType(item), ArrayCapacity(item))
+	}
+	return GetRustType(item)
+}
+
+// GetDefaultValueRust returns the constant expression initializing the field
+// holding an item
+func GetDefaultValueRust(item Item) string {
+	var value string
+	switch {
+	case item.Type == "container" && item.ContainerType != nil:
+		value = item.ContainerType.Name + "::DEFAULT"
+	case item.Type == "enum" && item.EnumType != nil:
+		value = EnumDefaultRust(item.EnumType)
+	case item.Type == "string":
+		return fmt.Sprintf("[0; %d]", item.MaxLength)
+	default:
+		switch WireType(item) {
+		case "float", "double":
+			value = "0.0"
+		case "bool":
+			value = "false"
+		default:
+			value = "0"
+		}
+	}
+
+	if item.IsArray {
+		return fmt.Sprintf("[%s; %d]", value, ArrayCapacity(item))
+	}
+	return value
+}
+
+// EnumDefaultRust returns the Rust variant used to initialize an enum item
+func EnumDefaultRust(enum *Enum) string {
+	if len(enum.Values) == 0 {
+		return enum.Name + "::default()"
+	}
+	return enum.Name + "::" + enum.Values[0].Name
+}
+
+// RustEncodeBytes returns a Rust expression borrowing the wire bytes of a
+// primitive value
+func RustEncodeBytes(itemType, byteOrder, expr string) string {
+	if itemType == "bool" {
+		return fmt.Sprintf("&[%s as u8]", expr)
+	}
+	if byteOrder == "big" {
+		return fmt.Sprintf("&%s.to_be_bytes()", expr)
+	}
+	return fmt.Sprintf("&%s.to_le_bytes()", expr)
+}
+
+// RustDecodeValue returns a Rust expression reading a primitive value at
+// data[off..], propagating short buffers with ?
+func RustDecodeValue(itemType, byteOrder string) string {
+	if itemType == "bool" {
+		return "read::<1>(data, off)?[0] != 0"
+	}
+	if byteOrder == "big" {
+		return fmt.Sprintf("%s::from_be_bytes(read(data, off)?)", RustTypeName(itemType))
+	}
+	return fmt.Sprintf("%s::from_le_bytes(read(data, off)?)", RustTypeName(itemType))
+}
+
+// ItemOffsets returns the byte offset of each item of a container, or -1 for
+// items that follow a variable-size item
+func ItemOffsets(container Container) []int {
+	offsets := make([]int, len(container.Items))
+	offset := 0
+	for i, item := range container.Items {
+		offsets[i] = offset
+		if offset < 0 {
+			continue
+		}
+		if ItemSize(item) != ItemMinSize(item) {
+			offset = -1
+			continue
+		}
+		offset += ItemSize(item)
+	}
+	return offsets
+}
+
+// DocTypeLabel returns a human-readable description of an item's wire type
+func DocTypeLabel(item Item) string {
+	var label string
+	switch item.Type {
+	case "enum":
+		label = fmt.Sprintf("%s (%s)", item.EnumType.Name, item.EnumType.Type)
+	case "container":
+		label = item.ContainerType.Name
+	case "bitfield":
+		label = fmt.Sprintf("bitfield (%s)", item.BaseType)
+	case "string":
+		if item.Encoding == "prefixed" {
+			label = fmt.Sprintf("string, up to %d bytes after a %s length", item.MaxLength, item.LengthType)
+		} else {
+			label = fmt.Sprintf("string, %d bytes NUL-padded", item.MaxLength)
+		}
+	default:
+		label = item.Type
+	}
+
+	switch {
+	case IsVariableArray(item) && item.LengthField != "":
+		label = fmt.Sprintf("%s[≤%d], counted by %s", label, item.MaxLength, item.LengthField)
+	case IsVariableArray(item):
+		label = fmt.Sprintf("%s[≤%d] after a %s count", label, item.MaxLength, item.LengthType)
+	case item.IsArray:
+		label = fmt.Sprintf("%s[%d]", label, item.Length)
+	}
+	if item.ByteOrder == "big" && PrimitiveSize(WireType(item)) > 1 {
+		label += ", big-endian"
+	}
+	return label
+}
+
+// MarkdownEscape escapes text for use inside a Markdown table cell
+func MarkdownEscape(s string) string {
+	return strings.NewReplacer("|", "\\|", "\n", " ").Replace(s)
 }
 
 // ToSnakeCase converts a string to snake_case
@@ -0,0 +1,61 @@
+package templates
+
+import (
+	"text/template"
+)
+
+// MarkdownTemplate generates Markdown reference documentation of every enum
+// and container of a config, including the byte layout of each container
+var MarkdownTemplate = template.Must(template.New("markdown").Funcs(templateFuncs).Parse(`# {{.Name}}
+
+Generated by uscdl. Multi-byte values are little-endian unless noted.
+{{- if .Containers}}
+
+## Containers
+{{- range .Containers}}
+
+### {{.Name}}
+
+{{.Description}}
+
+{{if eq (StructSize .) (MinStructSize .)}}Size: {{StructSize .}} bytes{{else}}Size: {{MinStructSize .}} to {{StructSize .}} bytes{{end}}, layout fingerprint ` + "`{{LayoutFingerprint .}}`" + `
+
+| Offset | Item | Type | Size | Units | Description |
+|-------:|------|------|-----:|-------|-------------|
+{{- $offsets := ItemOffsets .}}
+{{- range $i, $item := .Items}}
+| {{$offset := index $offsets $i}}{{if lt $offset 0}}variable{{else}}{{$offset}}{{end}} | {{$item.Name}} | {{DocTypeLabel $item | MarkdownEscape}} | {{if eq (ItemSize $item) (ItemMinSize $item)}}{{ItemSize $item}}{{else}}{{ItemMinSize $item}}–{{ItemSize $item}}{{end}} | {{MarkdownEscape $item.Units}} | {{MarkdownEscape $item.Description}} |
+{{- end}}
+{{- range .Items}}
+{{- if eq .Type "bitfield"}}
+
+Bits of ` + "`{{.Name}}`" + `:
+
+| Bit | Width | Name | Description |
+|----:|------:|------|-------------|
+{{- range .Bits}}
+| {{.Offset}} | {{.Width}} | {{.Name}} | {{MarkdownEscape .Description}} |
+{{- end}}
+{{- end}}
+{{- end}}
+{{- end}}
+{{- end}}
+{{- if .Enums}}
+
+## Enumerations
+{{- range .Enums}}
+
+### {{.Name}}
+
+{{.Description}}
+
+Encoded as {{.Type}}.
+
+| Value | Name | Description |
+|------:|------|-------------|
+{{- range .Values}}
+| {{.Value}} | {{.Name}} | {{MarkdownEscape .Description}} |
+{{- end}}
+{{- end}}
+{{- end}}
+`))
@@ -0,0 +1,63 @@
+package templates
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// File naming conventions for generated files
+const (
+	// NamingDefault lowercases C and Go file names and keeps TypeScript file
+	// names identical to the type name
+	NamingDefault = "default"
+	// NamingLower lowercases every file name
+	NamingLower = "lower"
+	// NamingSnake converts every file name to snake_case
+	NamingSnake = "snake"
+	// NamingPreserve keeps every file name identical to the type name
+	NamingPreserve = "preserve"
+)
+
+// NamingConventions lists the supported file naming conventions
+var NamingConventions = []string{NamingDefault, NamingLower, NamingSnake, NamingPreserve}
+
+// FileBase returns the file name without extension of the file generated for
+// a type. lang is "c", "ts" or "go".
+func FileBase(convention, lang, name string) (string, error) {
+	switch convention {
+	case NamingDefault, "":
+		if lang == "ts" {
+			return name, nil
+		}
+		return strings.ToLower(name), nil
+	case NamingLower:
+		return strings.ToLower(name), nil
+	case NamingSnake:
+		return ToSnakeCase(name), nil
+	case NamingPreserve:
+		return name, nil
+	default:
+		return "", fmt.Errorf("unknown naming convention %q", convention)
+	}
+}
+
+// WithNaming returns a copy of a template whose CFileName and TSFileName
+// functions, used for includes and imports, follow a naming convention
+func WithNaming(t *template.Template, convention string) (*template.Template, error) {
+	if _, err := FileBase(convention, "c", ""); err != nil {
+		return nil, err
+	}
+	clone, err := t.Clone()
+	if err != nil {
+		return nil, err
+	}
+	return withInclude(clone.Funcs(template.FuncMap{
+		"CFileName": func(name string) (string, error) {
+			return FileBase(convention, "c", name)
+		},
+		"TSFileName": func(name string) (string, error) {
+			return FileBase(convention, "ts", name)
+		},
+	})), nil
+}
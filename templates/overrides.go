@@ -0,0 +1,108 @@
+package templates
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// builtinNames lists the names of the built-in templates that a file of the
+// same name in an override directory replaces
+var builtinNames = []string{
+	"cendian", "cenum", "cheader", "csource",
+	"tsenum", "typescript",
+	"golang", "goenum",
+	"python", "rust", "markdown",
+}
+
+// BuiltinNames returns the names of the built-in templates
+func BuiltinNames() []string {
+	return append([]string(nil), builtinNames...)
+}
+
+// overrideFile is one user template file
+type overrideFile struct {
+	name string
+	text string
+}
+
+// Overrides holds user templates that replace or extend the built-in ones.
+// A file named after a built-in template, such as cheader.tmpl, replaces that
+// template. Every other .tmpl file is added to all templates under its base
+// name, so it can be invoked with {{template}} or include, and any {{define}}
+// blocks in it replace the built-in sub-templates of the same name.
+type Overrides struct {
+	replacements map[string]string
+	partials     []overrideFile
+}
+
+// LoadOverrides reads the .tmpl files at the top level of fsys and checks
+// that they parse with the built-in helper functions
+func LoadOverrides(fsys fs.FS) (*Overrides, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template directory: %w", err)
+	}
+
+	o := &Overrides{replacements: make(map[string]string)}
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".tmpl" {
+			continue
+		}
+		data, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template %s: %w", entry.Name(), err)
+		}
+		name := strings.TrimSuffix(entry.Name(), ".tmpl")
+		text := string(data)
+
+		check := withInclude(template.New(name).Funcs(templateFuncs))
+		if _, err := check.Parse(text); err != nil {
+			return nil, fmt.Errorf("failed to parse template %s: %w", entry.Name(), err)
+		}
+
+		if isBuiltin(name) {
+			o.replacements[name] = text
+		} else {
+			o.partials = append(o.partials, overrideFile{name: name, text: text})
+		}
+	}
+	sort.Slice(o.partials, func(i, j int) bool { return o.partials[i].name < o.partials[j].name })
+	return o, nil
+}
+
+// Apply returns a copy of a built-in template with the overrides added. A nil
+// Overrides returns the template unchanged.
+func (o *Overrides) Apply(t *template.Template) (*template.Template, error) {
+	if o == nil {
+		return t, nil
+	}
+	clone, err := t.Clone()
+	if err != nil {
+		return nil, err
+	}
+	for _, partial := range o.partials {
+		if _, err := clone.New(partial.name).Parse(partial.text); err != nil {
+			return nil, fmt.Errorf("failed to add template %s: %w", partial.name, err)
+		}
+	}
+	if text, ok := o.replacements[t.Name()]; ok {
+		if _, err := clone.Parse(text); err != nil {
+			return nil, fmt.Errorf("failed to replace template %s: %w", t.Name(), err)
+		}
+	}
+	return withInclude(clone), nil
+}
+
+// isBuiltin reports whether name is the name of a built-in template
+func isBuiltin(name string) bool {
+	for _, builtin := range builtinNames {
+		if name == builtin {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,214 @@
+package templates
+
+import (
+	"text/template"
+)
+
+// PythonTemplate generates a Python module holding an IntEnum per enum, a
+// dataclass per container with struct-based pack/unpack methods, and a
+// registry of every container in the config
+var PythonTemplate = template.Must(withInclude(template.New("python").Funcs(templateFuncs)).Parse(`"""
+{{.Name}}
+Generated by uscdl. Do not edit.
+"""
+
+from __future__ import annotations
+
+import struct
+from dataclasses import dataclass, field
+from enum import IntEnum
+from typing import Any, ClassVar, Dict, List, Tuple, Type
+
+
+def _unpack(fmt: str, data: bytes, offset: int, name: str) -> Tuple[Any, int]:
+    """Reads one struct value at offset and returns it with the next offset"""
+    size = struct.calcsize(fmt)
+    if offset + size > len(data):
+        raise ValueError(f"{name}: need {size} bytes at offset {offset}, have {len(data) - offset}")
+    return struct.unpack_from(fmt, data, offset)[0], offset + size
+
+
+def _take(data: bytes, offset: int, size: int, name: str) -> Tuple[bytes, int]:
+    """Reads size raw bytes at offset and returns them with the next offset"""
+    if offset + size > len(data):
+        raise ValueError(f"{name}: need {size} bytes at offset {offset}, have {len(data) - offset}")
+    return bytes(data[offset:offset + size]), offset + size
+{{- range .Enums}}
+
+
+class {{.Name}}(IntEnum):
+    """{{.Description}}"""
+{{- range .Values}}
+
+    {{PyName .Name}} = {{.Value}}
+    """{{.Description}}"""
+{{- end}}
+{{- end}}
+{{- range .Containers}}
+{{- $container := .}}
+
+
+@dataclass
+class {{.Name}}:
+    """{{.Description}}"""
+
+    MIN_SIZE: ClassVar[int] = {{CalculateMinStructSize .}}
+    """Minimum packed size of {{.Name}} in bytes"""
+    MAX_SIZE: ClassVar[int] = {{CalculateStructSize .}}
+    """Maximum packed size of {{.Name}} in bytes"""
+    FINGERPRINT: ClassVar[int] = {{LayoutFingerprint .}}
+    """Fingerprint of the wire layout of {{.Name}}"""
+{{- range .Items}}
+
+    {{.Name | PyFieldName}}: {{GetPyFieldType .}} = field({{GetDefaultValuePy .}}, metadata={"name": {{printf "%q" .Name}}, "description": {{printf "%q" .Description}}{{if .Units}}, "units": {{printf "%q" .Units}}{{end}}})
+    """{{.Description}}{{if .Units}} ({{.Units}}){{end}}"""
+{{- end}}
+{{- range .Items}}
+{{- if eq .Type "bitfield"}}
+{{- $item := .}}
+{{- range .Bits}}
+{{- $prop := PyFieldName (printf "%s_%s" $item.Name .Name)}}
+{{- $mask := printf "0x%X" (BitValueMask .)}}
+
+    @property
+    def {{$prop}}(self) -> {{if le .Width 1}}bool{{else}}int{{end}}:
+        """{{.Description}}"""
+        return {{if le .Width 1}}bool({{end}}(self.{{$item.Name | PyFieldName}} >> {{.Offset}}) & {{$mask}}{{if le .Width 1}}){{end}}
+
+    @{{$prop}}.setter
+    def {{$prop}}(self, value: {{if le .Width 1}}bool{{else}}int{{end}}) -> None:
+        self.{{$item.Name | PyFieldName}} = (self.{{$item.Name | PyFieldName}} & ~({{$mask}} << {{.Offset}})) | ((int(value) & {{$mask}}) << {{.Offset}})
+{{- end}}
+{{- end}}
+{{- end}}
+
+    def pack(self) -> bytes:
+        """Packs this {{.Name}} into bytes"""
+        buf = bytearray()
+{{- range .Items}}
+
+        # {{.Name}}
+{{- if .IsArray}}
+{{- if IsVariableArray .}}
+        if len(self.{{.Name | PyFieldName}}) > {{.MaxLength}}:
+            raise ValueError(f"{{.Name}}: {len(self.{{.Name | PyFieldName}})} elements exceed the maximum of {{.MaxLength}}")
+{{- if .LengthField}}
+        if len(self.{{.Name | PyFieldName}}) != self.{{.LengthField | PyFieldName}}:
+            raise ValueError(f"{{.Name}}: {len(self.{{.Name | PyFieldName}})} elements but {{.LengthField}} is {self.{{.LengthField | PyFieldName}}}")
+{{- else}}
+        buf += struct.pack("{{PyStructFormat .LengthType .ByteOrder}}", len(self.{{.Name | PyFieldName}}))
+{{- end}}
+{{- else}}
+        if len(self.{{.Name | PyFieldName}}) != {{.Length}}:
+            raise ValueError(f"{{.Name}}: expected {{.Length}} elements, got {len(self.{{.Name | PyFieldName}})}")
+{{- end}}
+        for value in self.{{.Name | PyFieldName}}:
+{{include "pyPackElement" (Element . "value") | Indent 12}}
+{{- else}}
+{{include "pyPackElement" (Element . (printf "self.%s" (PyFieldName .Name))) | Indent 8}}
+{{- end}}
+{{- end}}
+
+        return bytes(buf)
+
+    @classmethod
+    def check_fingerprint(cls, fingerprint: int) -> bool:
+        """Returns whether a peer's layout fingerprint matches this build"""
+        return fingerprint == cls.FINGERPRINT
+
+    @classmethod
+    def unpack(cls, data: bytes) -> {{.Name}}:
+        """Unpacks a {{.Name}} from bytes"""
+        obj, _ = cls.unpack_from(data)
+        return obj
+
+    @classmethod
+    def unpack_from(cls, data: bytes, offset: int = 0) -> Tuple[{{.Name}}, int]:
+        """Unpacks a {{.Name}} at offset and returns it with the next offset"""
+        obj = cls()
+{{- range .Items}}
+
+        # {{.Name}}
+{{- if .IsArray}}
+{{- if IsVariableArray .}}
+{{- if .LengthField}}
+        count = obj.{{.LengthField | PyFieldName}}
+{{- else}}
+        count, offset = _unpack("{{PyStructFormat .LengthType .ByteOrder}}", data, offset, "{{.Name}}")
+{{- end}}
+        if count > {{.MaxLength}}:
+            raise ValueError(f"{{.Name}}: {count} elements exceed the maximum of {{.MaxLength}}")
+{{- else}}
+        count = {{.Length}}
+{{- end}}
+        obj.{{.Name | PyFieldName}} = []
+        for _ in range(count):
+{{include "pyUnpackElement" (Element . "value") | Indent 12}}
+            obj.{{.Name | PyFieldName}}.append(value)
+{{- else}}
+{{include "pyUnpackElement" (Element . (printf "obj.%s" (PyFieldName .Name))) | Indent 8}}
+{{- end}}
+{{- end}}
+
+        return obj, offset
+{{- end}}
+
+
+CONTAINERS: Dict[str, Type[Any]] = {
+{{- range .Containers}}
+    "{{.Name}}": {{.Name}},
+{{- end}}
+}
+"""Every container of {{.Name}}, keyed by name"""
+
+ENUMS: Dict[str, Type[IntEnum]] = {
+{{- range .Enums}}
+    "{{.Name}}": {{.Name}},
+{{- end}}
+}
+"""Every enum of {{.Name}}, keyed by name"""
+
+{{- define "pyPackElement"}}
+{{- $item := .Item}}
+{{- if eq $item.Type "container"}}
+buf += {{.Expr}}.pack()
+{{- else if eq $item.Type "string"}}
+encoded = {{.Expr}}.encode("utf-8")
+if len(encoded) > {{$item.MaxLength}}:
+    raise ValueError(f"{{$item.Name}}: length {len(encoded)} exceeds the maximum of {{$item.MaxLength}}")
+{{- if eq $item.Encoding "prefixed"}}
+buf += struct.pack("{{PyStructFormat $item.LengthType $item.ByteOrder}}", len(encoded))
+buf += encoded
+{{- else}}
+buf += encoded.ljust({{$item.MaxLength}}, b"\0")
+{{- end}}
+{{- else if eq $item.Type "enum"}}
+buf += struct.pack("{{PyStructFormat (WireType $item) $item.ByteOrder}}", {{$item.Enum}}({{.Expr}}))
+{{- else}}
+buf += struct.pack("{{PyStructFormat (WireType $item) $item.ByteOrder}}", {{.Expr}})
+{{- end}}
+{{- end}}
+
+{{- define "pyUnpackElement"}}
+{{- $item := .Item}}
+{{- if eq $item.Type "container"}}
+{{.Expr}}, offset = {{$item.Container}}.unpack_from(data, offset)
+{{- else if eq $item.Type "string"}}
+{{- if eq $item.Encoding "prefixed"}}
+length, offset = _unpack("{{PyStructFormat $item.LengthType $item.ByteOrder}}", data, offset, "{{$item.Name}}")
+if length > {{$item.MaxLength}}:
+    raise ValueError(f"{{$item.Name}}: length {length} exceeds the maximum of {{$item.MaxLength}}")
+raw, offset = _take(data, offset, length, "{{$item.Name}}")
+{{.Expr}} = raw.decode("utf-8")
+{{- else}}
+raw, offset = _take(data, offset, {{$item.MaxLength}}, "{{$item.Name}}")
+{{.Expr}} = raw.split(b"\0", 1)[0].decode("utf-8")
+{{- end}}
+{{- else if eq $item.Type "enum"}}
+raw, offset = _unpack("{{PyStructFormat (WireType $item) $item.ByteOrder}}", data, offset, "{{$item.Name}}")
+{{.Expr}} = {{$item.Enum}}(raw)
+{{- else}}
+{{.Expr}}, offset = _unpack("{{PyStructFormat (WireType $item) $item.ByteOrder}}", data, offset, "{{$item.Name}}")
+{{- end}}
+{{- end}}
+`))
@@ -0,0 +1,299 @@
+package templates
+
+import (
+	"text/template"
+)
+
+// RustTemplate generates a no_std Rust module holding an enum per enum and a
+// struct per container with encode/decode functions. Strings and
+// variable-length arrays are stored in fixed-capacity arrays so no allocator
+// is needed.
+var RustTemplate = template.Must(withInclude(template.New("rust").Funcs(templateFuncs)).Parse(`//! {{.Name}}
+//! Generated by uscdl. Do not edit.
+
+#![allow(dead_code, clippy::identity_op, clippy::upper_case_acronyms)]
+
+/// Errors returned when encoding or decoding
+#[derive(Debug, Clone, Copy, PartialEq, Eq)]
+pub enum Error {
+    /// The buffer is too short for the encoded data
+    BufferTooSmall,
+    /// A raw value does not name a member of its enum
+    InvalidEnum,
+    /// A count or length exceeds its declared maximum
+    LengthTooLong,
+}
+
+/// Reads N bytes at off
+fn read<const N: usize>(data: &[u8], off: usize) -> Result<[u8; N], Error> {
+    let bytes = data.get(off..off + N).ok_or(Error::BufferTooSmall)?;
+    let mut out = [0u8; N];
+    out.copy_from_slice(bytes);
+    Ok(out)
+}
+
+/// Writes bytes at off
+fn write(buf: &mut [u8], off: usize, bytes: &[u8]) -> Result<(), Error> {
+    let dst = buf
+        .get_mut(off..off + bytes.len())
+        .ok_or(Error::BufferTooSmall)?;
+    dst.copy_from_slice(bytes);
+    Ok(())
+}
+
+/// Returns the length of a NUL-padded string
+fn str_len(bytes: &[u8]) -> usize {
+    bytes.iter().position(|&b| b == 0).unwrap_or(bytes.len())
+}
+{{- range .Enums}}
+
+/// {{.Description}}
+#[derive(Debug, Clone, Copy, PartialEq, Eq)]
+#[repr({{RustTypeName .Type}})]
+pub enum {{.Name}} {
+{{- range .Values}}
+    /// {{.Description}}
+    {{RustName .Name}} = {{.Value}},
+{{- end}}
+}
+
+impl {{.Name}} {
+    /// Returns the member named by a raw wire value
+    pub const fn from_raw(value: {{RustTypeName .Type}}) -> Option<Self> {
+        match value {
+{{- range .Values}}
+            {{.Value}} => Some(Self::{{RustName .Name}}),
+{{- end}}
+            _ => None,
+        }
+    }
+
+    /// Returns the raw wire value of the member
+    pub const fn to_raw(self) -> {{RustTypeName .Type}} {
+        self as {{RustTypeName .Type}}
+    }
+}
+
+impl TryFrom<{{RustTypeName .Type}}> for {{.Name}} {
+    type Error = Error;
+
+    fn try_from(value: {{RustTypeName .Type}}) -> Result<Self, Error> {
+        Self::from_raw(value).ok_or(Error::InvalidEnum)
+    }
+}
+{{- end}}
+{{- range .Containers}}
+
+/// {{.Description}}
+#[derive(Debug, Clone, Copy, PartialEq)]
+pub struct {{.Name}} {
+{{- range .Items}}
+{{- if and (IsVariableArray .) (not .LengthField)}}
+    /// Number of valid elements in {{.Name}}
+    pub {{RustFieldName (ArrayCountMemberC .)}}: {{RustTypeName .LengthType}},
+{{- end}}
+    /// {{.Description}}{{if .Units}} ({{.Units}}){{end}}
+    pub {{.Name | RustFieldName}}: {{GetRustFieldType .}},
+{{- end}}
+}
+
+impl {{.Name}} {
+    /// Minimum encoded size in bytes
+    pub const MIN_SIZE: usize = {{CalculateMinStructSize .}};
+    /// Maximum encoded size in bytes
+    pub const MAX_SIZE: usize = {{CalculateStructSize .}};
+    /// Fingerprint of the wire layout; peers built from a different layout
+    /// have a different value
+    pub const FINGERPRINT: u32 = {{LayoutFingerprint .}};
+
+    /// Value with every field at its default
+    pub const DEFAULT: Self = Self {
+{{- range .Items}}
+{{- if and (IsVariableArray .) (not .LengthField)}}
+        {{RustFieldName (ArrayCountMemberC .)}}: 0,
+{{- end}}
+        {{.Name | RustFieldName}}: {{GetDefaultValueRust .}},
+{{- end}}
+    };
+{{- range .Items}}
+{{- $item := .}}
+{{- if eq .Type "string"}}
+
+    /// Returns the bytes of {{.Name}} up to its first NUL
+    pub fn {{printf "%s_bytes" (ToSnakeCase .Name) | RustName}}(&self) -> &[u8] {
+        &self.{{.Name | RustFieldName}}[..str_len(&self.{{.Name | RustFieldName}})]
+    }
+{{- else if IsVariableArray .}}
+
+    /// Returns the valid elements of {{.Name}}
+    pub fn {{printf "%s_slice" (ToSnakeCase .Name) | RustName}}(&self) -> &[{{GetRustType .}}] {
+        let count = (self.{{RustFieldName (ArrayCountMemberC .)}} as usize).min({{.MaxLength}});
+        &self.{{.Name | RustFieldName}}[..count]
+    }
+{{- else if eq .Type "bitfield"}}
+{{- range .Bits}}
+{{- $getter := RustFieldName (printf "%s_%s" $item.Name .Name)}}
+{{- $mask := printf "0x%X" (BitValueMask .)}}
+{{- if le .Width 1}}
+
+    /// {{.Description}}
+    pub fn {{$getter}}(&self) -> bool {
+        (self.{{$item.Name | RustFieldName}} >> {{.Offset}}) & {{$mask}} != 0
+    }
+
+    /// Sets or clears the {{.Name}} bit of {{$item.Name}}
+    pub fn set_{{ToSnakeCase (printf "%s_%s" $item.Name .Name)}}(&mut self, value: bool) {
+        self.{{$item.Name | RustFieldName}} = (self.{{$item.Name | RustFieldName}} & !({{$mask}} << {{.Offset}})) | ((value as {{GetRustType $item}}) << {{.Offset}});
+    }
+{{- else}}
+
+    /// {{.Description}}
+    pub fn {{$getter}}(&self) -> {{GetRustType $item}} {
+        (self.{{$item.Name | RustFieldName}} >> {{.Offset}}) & {{$mask}}
+    }
+
+    /// Stores value in the {{.Name}} field of {{$item.Name}}
+    pub fn set_{{ToSnakeCase (printf "%s_%s" $item.Name .Name)}}(&mut self, value: {{GetRustType $item}}) {
+        self.{{$item.Name | RustFieldName}} = (self.{{$item.Name | RustFieldName}} & !({{$mask}} << {{.Offset}})) | ((value & {{$mask}}) << {{.Offset}});
+    }
+{{- end}}
+{{- end}}
+{{- end}}
+{{- end}}
+
+    /// Returns whether a peer's layout fingerprint matches this build
+    pub const fn check_fingerprint(fingerprint: u32) -> bool {
+        fingerprint == Self::FINGERPRINT
+    }
+
+    /// Encodes into buf and returns the number of bytes written
+    pub fn encode(&self, buf: &mut [u8]) -> Result<usize, Error> {
+        let mut off = 0;
+{{- range .Items}}
+
+        // {{.Name}}
+{{- if .IsArray}}
+{{- if IsVariableArray .}}
+        let count = self.{{RustFieldName (ArrayCountMemberC .)}} as usize;
+        if count > {{.MaxLength}} {
+            return Err(Error::LengthTooLong);
+        }
+{{- if not .LengthField}}
+        write(buf, off, {{RustEncodeBytes .LengthType .ByteOrder (printf "self.%s" (RustFieldName (ArrayCountMemberC .)))}})?;
+        off += {{PrimitiveSize .LengthType}};
+{{- end}}
+{{- else}}
+        let count = {{.Length}};
+{{- end}}
+        for i in 0..count {
+{{include "rustEncodeElement" (Element . (printf "self.%s[i]" (RustFieldName .Name))) | Indent 12}}
+        }
+{{- else}}
+{{include "rustEncodeElement" (Element . (printf "self.%s" (RustFieldName .Name))) | Indent 8}}
+{{- end}}
+{{- end}}
+
+        Ok(off)
+    }
+
+    /// Decodes from the start of data
+    pub fn decode(data: &[u8]) -> Result<Self, Error> {
+        Self::decode_from(data).map(|(value, _)| value)
+    }
+
+    /// Decodes from the start of data and returns the value with the number
+    /// of bytes consumed
+    pub fn decode_from(data: &[u8]) -> Result<(Self, usize), Error> {
+        let mut value = Self::DEFAULT;
+        let mut off = 0;
+{{- range .Items}}
+
+        // {{.Name}}
+{{- if .IsArray}}
+{{- if IsVariableArray .}}
+{{- if not .LengthField}}
+        value.{{RustFieldName (ArrayCountMemberC .)}} = {{RustDecodeValue .LengthType .ByteOrder}};
+        off += {{PrimitiveSize .LengthType}};
+{{- end}}
+        let count = value.{{RustFieldName (ArrayCountMemberC .)}} as usize;
+        if count > {{.MaxLength}} {
+            return Err(Error::LengthTooLong);
+        }
+{{- else}}
+        let count = {{.Length}};
+{{- end}}
+        for i in 0..count {
+{{include "rustDecodeElement" (Element . (printf "value.%s[i]" (RustFieldName .Name))) | Indent 12}}
+        }
+{{- else}}
+{{include "rustDecodeElement" (Element . (printf "value.%s" (RustFieldName .Name))) | Indent 8}}
+{{- end}}
+{{- end}}
+
+        Ok((value, off))
+    }
+}
+
+impl Default for {{.Name}} {
+    fn default() -> Self {
+        Self::DEFAULT
+    }
+}
+{{- end}}
+
+{{- define "rustEncodeElement"}}
+{{- $item := .Item}}
+{{- if eq $item.Type "container"}}
+off += {{.Expr}}.encode(&mut buf[off..])?;
+{{- else if eq $item.Type "string"}}
+{{- if eq $item.Encoding "prefixed"}}
+let len = str_len(&{{.Expr}});
+write(buf, off, {{RustEncodeBytes $item.LengthType $item.ByteOrder (printf "(len as %s)" (RustTypeName $item.LengthType))}})?;
+off += {{PrimitiveSize $item.LengthType}};
+write(buf, off, &{{.Expr}}[..len])?;
+off += len;
+{{- else}}
+let len = str_len(&{{.Expr}});
+let mut field = [0u8; {{$item.MaxLength}}];
+field[..len].copy_from_slice(&{{.Expr}}[..len]);
+write(buf, off, &field)?;
+off += {{$item.MaxLength}};
+{{- end}}
+{{- else if eq $item.Type "enum"}}
+write(buf, off, {{RustEncodeBytes (WireType $item) $item.ByteOrder (printf "%s.to_raw()" .Expr)}})?;
+off += {{PrimitiveSize (WireType $item)}};
+{{- else}}
+write(buf, off, {{RustEncodeBytes (WireType $item) $item.ByteOrder .Expr}})?;
+off += {{PrimitiveSize (WireType $item)}};
+{{- end}}
+{{- end}}
+
+{{- define "rustDecodeElement"}}
+{{- $item := .Item}}
+{{- if eq $item.Type "container"}}
+let (element, consumed) = {{$item.Container}}::decode_from(&data[off..])?;
+{{.Expr}} = element;
+off += consumed;
+{{- else if eq $item.Type "string"}}
+{{- if eq $item.Encoding "prefixed"}}
+let len = {{RustDecodeValue $item.LengthType $item.ByteOrder}} as usize;
+off += {{PrimitiveSize $item.LengthType}};
+if len > {{$item.MaxLength}} {
+    return Err(Error::LengthTooLong);
+}
+let bytes = data.get(off..off + len).ok_or(Error::BufferTooSmall)?;
+{{.Expr}}[..len].copy_from_slice(bytes);
+off += len;
+{{- else}}
+{{.Expr}} = read(data, off)?;
+off += {{$item.MaxLength}};
+{{- end}}
+{{- else if eq $item.Type "enum"}}
+{{.Expr}} = {{$item.Enum}}::try_from({{RustDecodeValue (WireType $item) $item.ByteOrder}})?;
+off += {{PrimitiveSize (WireType $item)}};
+{{- else}}
+{{.Expr}} = {{RustDecodeValue (WireType $item) $item.ByteOrder}};
+off += {{PrimitiveSize (WireType $item)}};
+{{- end}}
+{{- end}}
+`))
@@ -0,0 +1,45 @@
+package templates
+
+import (
+	"text/template"
+)
+
+// TypeScriptEnumTemplate generates a TypeScript enum with value-name lookup tables
+var TypeScriptEnumTemplate = template.Must(template.New("tsenum").Funcs(templateFuncs).Parse(`/**
+* {{.Name}}
+* {{.Description}}
+*/
+export enum {{.Name}} {
+{{- range .Values}}
+  /** {{.Description}} */
+  {{.Name}} = {{.Value}},
+{{- end}}
+}
+
+/**
+* Maps each {{.Name}} value to its name
+*/
+export const {{.Name}}Names: Record<number, string> = {
+{{- range .Values}}
+  '{{.Value}}': '{{.Name}}',
+{{- end}}
+};
+
+/**
+* Maps each {{.Name}} value to its description
+*/
+export const {{.Name}}Descriptions: Record<number, string> = {
+{{- range .Values}}
+  '{{.Value}}': {{printf "%q" .Description}},
+{{- end}}
+};
+
+/**
+* Checks whether a raw wire value is a legal {{.Name}}
+* @param value The raw value to check
+* @returns True if the value names a {{.Name}} member
+*/
+export function is{{.Name}}(value: number): value is {{.Name}} {
+  return Object.prototype.hasOwnProperty.call({{.Name}}Names, value);
+}
+`))
@@ -9,7 +9,10 @@ var TypeScriptTemplate = template.Must(template.New("typescript").Funcs(template
 * {{.Name}}
 * {{.Description}}
 */
-export interface {{.Name}} {
+{{range UsedEnums .}}import { {{.Name}}, is{{.Name}} } from './{{TSFileName .Name}}';
+{{end}}{{range UsedContainers .}}import { {{.Name}}, create{{.Name}}, read{{.Name}}, write{{.Name}} } from './{{TSFileName .Name}}';
+{{end}}{{if or (UsedEnums .) (UsedContainers .)}}
+{{end}}export interface {{.Name}} {
 {{- range .Items}}
   {{- if .Units}}
   /** {{.Description}} ({{.Units}}) */
@@ -20,6 +23,24 @@ export interface {{.Name}} {
 {{- end}}
 }
 
+/** Minimum serialized size of {{.Name}} in bytes */
+export const {{.Name}}MinSize = {{CalculateMinStructSize .}};
+
+/** Maximum serialized size of {{.Name}} in bytes */
+export const {{.Name}}MaxSize = {{CalculateStructSize .}};
+
+/** Fingerprint of the wire layout of {{.Name}}; peers built from a different layout have a different value */
+export const {{.Name}}Fingerprint = {{LayoutFingerprint .}};
+
+/**
+* Checks a peer's {{.Name}} layout fingerprint against this build
+* @param fingerprint The fingerprint reported by the peer
+* @returns True if both sides use the same wire layout
+*/
+export function check{{.Name}}Fingerprint(fingerprint: number): boolean {
+  return fingerprint === {{.Name}}Fingerprint;
+}
+
 /**
 * Creates a default {{.Name}} object
 * @returns A new {{.Name}} with default values
@@ -38,37 +59,90 @@ export function create{{.Name}}(): {{.Name}} {
 * @returns An ArrayBuffer containing the serialized data
 */
 export function serialize{{.Name}}(data: {{.Name}}): ArrayBuffer {
-  const buffer = new ArrayBuffer({{CalculateStructSize .}});
-  const view = new DataView(buffer);
-  let offset = 0;
+  const buffer = new ArrayBuffer({{.Name}}MaxSize);
+  const length = write{{.Name}}(new DataView(buffer), 0, data);
+  return buffer.slice(0, length);
+}
+
+/**
+* Deserializes an ArrayBuffer to a {{.Name}} object
+* @param buffer The ArrayBuffer containing serialized data
+* @returns A {{.Name}} object with the deserialized data
+*/
+export function deserialize{{.Name}}(buffer: ArrayBuffer): {{.Name}} {
+  const [result] = read{{.Name}}(new DataView(buffer), 0);
+  return result;
+}
+
+/**
+* Writes a {{.Name}} object into a DataView
+* @param view The DataView to write into
+* @param offset The byte offset to start writing at
+* @param data The {{.Name}} object to write
+* @returns The byte offset just past the written data
+*/
+export function write{{.Name}}(view: DataView, offset: number, data: {{.Name}}): number {
 
   {{- range .Items}}
   {{- if .IsArray}}
+  {{- if IsVariableArray .}}
+  {{- if .LengthField}}
+  // {{.Name}} carries up to {{.MaxLength}} elements counted by {{.LengthField}}
+  const {{.Name}}Count = Number(data.{{.LengthField}});
+  if (data.{{.Name}}.length !== {{.Name}}Count) {
+    throw new RangeError('{{.Name}} has ' + data.{{.Name}}.length + ' elements but {{.LengthField}} is ' + {{.Name}}Count);
+  }
+  {{- else}}
+  // {{.Name}} carries up to {{.MaxLength}} elements after a {{.LengthType}} count
+  const {{.Name}}Count = data.{{.Name}}.length;
+  {{- end}}
+  if ({{.Name}}Count > {{.MaxLength}}) {
+    throw new RangeError('{{.Name}} exceeds {{.MaxLength}} elements');
+  }
+  {{- if not .LengthField}}
+  view.set{{GetDataViewType .LengthType}}(offset, {{.Name}}Count{{GetTSLengthEndianArg .}});
+  offset += {{GetTypeSizeC .LengthType}};
+  {{- end}}
+  {{- end}}
   // Serialize {{.Name}} array
-  for (let i = 0; i < {{.Length}}; i++) {
-    {{- if eq .Type "uint8"}}
+  for (let i = 0; i < {{ArrayBoundTS .}}; i++) {
+    {{- if eq .Type "container"}}
+    offset = write{{.Container}}(view, offset, data.{{.Name}}[i]);
+    {{- else if eq .Type "enum"}}
+    if (!is{{.Enum}}(data.{{.Name}}[i])) {
+      throw new RangeError('Invalid {{.Enum}} value in {{.Name}}: ' + data.{{.Name}}[i]);
+    }
+    view.set{{GetDataViewType .EnumType.Type}}(offset, data.{{.Name}}[i]{{GetTSLittleEndianArg .}});
+    offset += {{GetTypeSizeC .EnumType.Type}};
+    {{- else if eq .Type "uint8"}}
     view.setUint8(offset, data.{{.Name}}[i]);
     offset += 1;
     {{- else if eq .Type "uint16"}}
-    view.setUint16(offset, data.{{.Name}}[i], {{if eq .ByteOrder "little"}}true{{else}}false{{end}});
+    view.setUint16(offset, data.{{.Name}}[i]{{GetTSLittleEndianArg .}});
     offset += 2;
     {{- else if eq .Type "uint32"}}
-    view.setUint32(offset, data.{{.Name}}[i], {{if eq .ByteOrder "little"}}true{{else}}false{{end}});
+    view.setUint32(offset, data.{{.Name}}[i]{{GetTSLittleEndianArg .}});
     offset += 4;
     {{- else if eq .Type "int8"}}
     view.setInt8(offset, data.{{.Name}}[i]);
     offset += 1;
     {{- else if eq .Type "int16"}}
-    view.setInt16(offset, data.{{.Name}}[i], {{if eq .ByteOrder "little"}}true{{else}}false{{end}});
+    view.setInt16(offset, data.{{.Name}}[i]{{GetTSLittleEndianArg .}});
     offset += 2;
     {{- else if eq .Type "int32"}}
-    view.setInt32(offset, data.{{.Name}}[i], {{if eq .ByteOrder "little"}}true{{else}}false{{end}});
+    view.setInt32(offset, data.{{.Name}}[i]{{GetTSLittleEndianArg .}});
     offset += 4;
+    {{- else if eq .Type "uint64"}}
+    view.setBigUint64(offset, data.{{.Name}}[i]{{GetTSLittleEndianArg .}});
+    offset += 8;
+    {{- else if eq .Type "int64"}}
+    view.setBigInt64(offset, data.{{.Name}}[i]{{GetTSLittleEndianArg .}});
+    offset += 8;
     {{- else if eq .Type "float"}}
-    view.setFloat32(offset, data.{{.Name}}[i], {{if eq .ByteOrder "little"}}true{{else}}false{{end}});
+    view.setFloat32(offset, data.{{.Name}}[i]{{GetTSLittleEndianArg .}});
     offset += 4;
     {{- else if eq .Type "double"}}
-    view.setFloat64(offset, data.{{.Name}}[i], {{if eq .ByteOrder "little"}}true{{else}}false{{end}});
+    view.setFloat64(offset, data.{{.Name}}[i]{{GetTSLittleEndianArg .}});
     offset += 8;
     {{- else if eq .Type "bool"}}
     view.setUint8(offset, data.{{.Name}}[i] ? 1 : 0);
@@ -77,82 +151,156 @@ export function serialize{{.Name}}(data: {{.Name}}): ArrayBuffer {
   }
   {{- else}}
   // Serialize {{.Name}} scalar
-  {{- if eq .Type "uint8"}}
+  {{- if eq .Type "container"}}
+  offset = write{{.Container}}(view, offset, data.{{.Name}});
+  {{- else if eq .Type "bitfield"}}
+  let {{.Name}}Packed = 0;
+  {{- $item := .}}
+  {{- range .Bits}}
+  {{- if le .Width 1}}
+  {{$item.Name}}Packed |= (data.{{$item.Name}}.{{.Name}} ? 1 : 0) << {{.Offset}};
+  {{- else}}
+  if (data.{{$item.Name}}.{{.Name}} < 0 || data.{{$item.Name}}.{{.Name}} > {{BitValueMask .}}) {
+    throw new RangeError('{{$item.Name}}.{{.Name}} does not fit in {{.Width}} bits: ' + data.{{$item.Name}}.{{.Name}});
+  }
+  {{$item.Name}}Packed |= (data.{{$item.Name}}.{{.Name}} & {{BitValueMask .}}) << {{.Offset}};
+  {{- end}}
+  {{- end}}
+  view.set{{GetDataViewType .BaseType}}(offset, {{.Name}}Packed >>> 0{{GetTSLittleEndianArg .}});
+  offset += {{GetTypeSizeC .BaseType}};
+  {{- else if eq .Type "enum"}}
+  if (!is{{.Enum}}(data.{{.Name}})) {
+    throw new RangeError('Invalid {{.Enum}} value for {{.Name}}: ' + data.{{.Name}});
+  }
+  view.set{{GetDataViewType .EnumType.Type}}(offset, data.{{.Name}}{{GetTSLittleEndianArg .}});
+  offset += {{GetTypeSizeC .EnumType.Type}};
+  {{- else if eq .Type "uint8"}}
   view.setUint8(offset, data.{{.Name}});
   offset += 1;
   {{- else if eq .Type "uint16"}}
-  view.setUint16(offset, data.{{.Name}}, {{if eq .ByteOrder "little"}}true{{else}}false{{end}});
+  view.setUint16(offset, data.{{.Name}}{{GetTSLittleEndianArg .}});
   offset += 2;
   {{- else if eq .Type "uint32"}}
-  view.setUint32(offset, data.{{.Name}}, {{if eq .ByteOrder "little"}}true{{else}}false{{end}});
+  view.setUint32(offset, data.{{.Name}}{{GetTSLittleEndianArg .}});
   offset += 4;
   {{- else if eq .Type "int8"}}
   view.setInt8(offset, data.{{.Name}});
   offset += 1;
   {{- else if eq .Type "int16"}}
-  view.setInt16(offset, data.{{.Name}}, {{if eq .ByteOrder "little"}}true{{else}}false{{end}});
+  view.setInt16(offset, data.{{.Name}}{{GetTSLittleEndianArg .}});
   offset += 2;
   {{- else if eq .Type "int32"}}
-  view.setInt32(offset, data.{{.Name}}, {{if eq .ByteOrder "little"}}true{{else}}false{{end}});
+  view.setInt32(offset, data.{{.Name}}{{GetTSLittleEndianArg .}});
   offset += 4;
+  {{- else if eq .Type "uint64"}}
+  view.setBigUint64(offset, data.{{.Name}}{{GetTSLittleEndianArg .}});
+  offset += 8;
+  {{- else if eq .Type "int64"}}
+  view.setBigInt64(offset, data.{{.Name}}{{GetTSLittleEndianArg .}});
+  offset += 8;
   {{- else if eq .Type "float"}}
-  view.setFloat32(offset, data.{{.Name}}, {{if eq .ByteOrder "little"}}true{{else}}false{{end}});
+  view.setFloat32(offset, data.{{.Name}}{{GetTSLittleEndianArg .}});
   offset += 4;
   {{- else if eq .Type "double"}}
-  view.setFloat64(offset, data.{{.Name}}, {{if eq .ByteOrder "little"}}true{{else}}false{{end}});
+  view.setFloat64(offset, data.{{.Name}}{{GetTSLittleEndianArg .}});
   offset += 8;
   {{- else if eq .Type "bool"}}
   view.setUint8(offset, data.{{.Name}} ? 1 : 0);
   offset += 1;
   {{- else if eq .Type "string"}}
-  // String serialization not fully implemented
-  // This is a placeholder for string handling
-  offset += 4; // Allocate space for string length
+  {
+    const bytes = new TextEncoder().encode(data.{{.Name}});
+    if (bytes.length > {{.MaxLength}}) {
+      throw new RangeError('{{.Name}} exceeds {{.MaxLength}} bytes');
+    }
+    {{- if eq .Encoding "prefixed"}}
+    // Length-prefixed string: {{.LengthType}} byte count followed by the bytes
+    view.set{{GetDataViewType .LengthType}}(offset, bytes.length{{GetTSLengthEndianArg .}});
+    offset += {{GetTypeSizeC .LengthType}};
+    new Uint8Array(view.buffer, view.byteOffset + offset, bytes.length).set(bytes);
+    offset += bytes.length;
+    {{- else}}
+    // Fixed-capacity string: {{.MaxLength}} bytes padded with NUL
+    const field = new Uint8Array(view.buffer, view.byteOffset + offset, {{.MaxLength}});
+    field.fill(0);
+    field.set(bytes);
+    offset += {{.MaxLength}};
+    {{- end}}
+  }
   {{- end}}
   {{- end}}
   {{- end}}
 
-  return buffer;
+  return offset;
 }
 
 /**
-* Deserializes an ArrayBuffer to a {{.Name}} object
-* @param buffer The ArrayBuffer containing serialized data
-* @returns A {{.Name}} object with the deserialized data
+* Reads a {{.Name}} object from a DataView
+* @param view The DataView to read from
+* @param offset The byte offset to start reading at
+* @returns The {{.Name}} object and the byte offset just past it
 */
-export function deserialize{{.Name}}(buffer: ArrayBuffer): {{.Name}} {
-  const view = new DataView(buffer);
-  let offset = 0;
+export function read{{.Name}}(view: DataView, offset: number): [{{.Name}}, number] {
   const result = create{{.Name}}();
 
   {{- range .Items}}
   {{- if .IsArray}}
+  {{- if IsVariableArray .}}
+  {{- if .LengthField}}
+  // {{.Name}} carries up to {{.MaxLength}} elements counted by {{.LengthField}}
+  const {{.Name}}Count = Number(result.{{.LengthField}});
+  {{- else}}
+  // {{.Name}} carries up to {{.MaxLength}} elements after a {{.LengthType}} count
+  const {{.Name}}Count = view.get{{GetDataViewType .LengthType}}(offset{{GetTSLengthEndianArg .}});
+  offset += {{GetTypeSizeC .LengthType}};
+  {{- end}}
+  if ({{.Name}}Count < 0 || {{.Name}}Count > {{.MaxLength}}) {
+    throw new RangeError('{{.Name}} count ' + {{.Name}}Count + ' exceeds {{.MaxLength}} elements');
+  }
+  {{- end}}
   // Deserialize {{.Name}} array
-  const {{.Name}}Array = [];
-  for (let i = 0; i < {{.Length}}; i++) {
-    {{- if eq .Type "uint8"}}
+  const {{.Name}}Array: {{GetTSType .}} = [];
+  for (let i = 0; i < {{ArrayBoundTS .}}; i++) {
+    {{- if eq .Type "container"}}
+    const [value, next] = read{{.Container}}(view, offset);
+    {{.Name}}Array.push(value);
+    offset = next;
+    {{- else if eq .Type "enum"}}
+    const value = view.get{{GetDataViewType .EnumType.Type}}(offset{{GetTSLittleEndianArg .}});
+    if (!is{{.Enum}}(value)) {
+      throw new RangeError('Invalid {{.Enum}} value in {{.Name}}: ' + value);
+    }
+    {{.Name}}Array.push(value);
+    offset += {{GetTypeSizeC .EnumType.Type}};
+    {{- else if eq .Type "uint8"}}
     {{.Name}}Array.push(view.getUint8(offset));
     offset += 1;
     {{- else if eq .Type "uint16"}}
-    {{.Name}}Array.push(view.getUint16(offset, {{if eq .ByteOrder "little"}}true{{else}}false{{end}}));
+    {{.Name}}Array.push(view.getUint16(offset{{GetTSLittleEndianArg .}}));
     offset += 2;
     {{- else if eq .Type "uint32"}}
-    {{.Name}}Array.push(view.getUint32(offset, {{if eq .ByteOrder "little"}}true{{else}}false{{end}}));
+    {{.Name}}Array.push(view.getUint32(offset{{GetTSLittleEndianArg .}}));
     offset += 4;
     {{- else if eq .Type "int8"}}
     {{.Name}}Array.push(view.getInt8(offset));
     offset += 1;
     {{- else if eq .Type "int16"}}
-    {{.Name}}Array.push(view.getInt16(offset, {{if eq .ByteOrder "little"}}true{{else}}false{{end}}));
+    {{.Name}}Array.push(view.getInt16(offset{{GetTSLittleEndianArg .}}));
     offset += 2;
     {{- else if eq .Type "int32"}}
-    {{.Name}}Array.push(view.getInt32(offset, {{if eq .ByteOrder "little"}}true{{else}}false{{end}}));
+    {{.Name}}Array.push(view.getInt32(offset{{GetTSLittleEndianArg .}}));
     offset += 4;
+    {{- else if eq .Type "uint64"}}
+    {{.Name}}Array.push(view.getBigUint64(offset{{GetTSLittleEndianArg .}}));
+    offset += 8;
+    {{- else if eq .Type "int64"}}
+    {{.Name}}Array.push(view.getBigInt64(offset{{GetTSLittleEndianArg .}}));
+    offset += 8;
     {{- else if eq .Type "float"}}
-    {{.Name}}Array.push(view.getFloat32(offset, {{if eq .ByteOrder "little"}}true{{else}}false{{end}}));
+    {{.Name}}Array.push(view.getFloat32(offset{{GetTSLittleEndianArg .}}));
     offset += 4;
     {{- else if eq .Type "double"}}
-    {{.Name}}Array.push(view.getFloat64(offset, {{if eq .ByteOrder "little"}}true{{else}}false{{end}}));
+    {{.Name}}Array.push(view.getFloat64(offset{{GetTSLittleEndianArg .}}));
     offset += 8;
     {{- else if eq .Type "bool"}}
     {{.Name}}Array.push(view.getUint8(offset) !== 0);
@@ -162,41 +310,82 @@ export function deserialize{{.Name}}(buffer: ArrayBuffer): {{.Name}} {
   result.{{.Name}} = {{.Name}}Array;
   {{- else}}
   // Deserialize {{.Name}} scalar
-  {{- if eq .Type "uint8"}}
+  {{- if eq .Type "container"}}
+  [result.{{.Name}}, offset] = read{{.Container}}(view, offset);
+  {{- else if eq .Type "bitfield"}}
+  const {{.Name}}Packed = view.get{{GetDataViewType .BaseType}}(offset{{GetTSLittleEndianArg .}});
+  {{- $item := .}}
+  {{- range .Bits}}
+  {{- if le .Width 1}}
+  result.{{$item.Name}}.{{.Name}} = (({{$item.Name}}Packed >>> {{.Offset}}) & 1) !== 0;
+  {{- else}}
+  result.{{$item.Name}}.{{.Name}} = ({{$item.Name}}Packed >>> {{.Offset}}) & {{BitValueMask .}};
+  {{- end}}
+  {{- end}}
+  offset += {{GetTypeSizeC .BaseType}};
+  {{- else if eq .Type "enum"}}
+  const {{.Name}}Value = view.get{{GetDataViewType .EnumType.Type}}(offset{{GetTSLittleEndianArg .}});
+  if (!is{{.Enum}}({{.Name}}Value)) {
+    throw new RangeError('Invalid {{.Enum}} value for {{.Name}}: ' + {{.Name}}Value);
+  }
+  result.{{.Name}} = {{.Name}}Value;
+  offset += {{GetTypeSizeC .EnumType.Type}};
+  {{- else if eq .Type "uint8"}}
   result.{{.Name}} = view.getUint8(offset);
   offset += 1;
   {{- else if eq .Type "uint16"}}
-  result.{{.Name}} = view.getUint16(offset, {{if eq .ByteOrder "little"}}true{{else}}false{{end}});
+  result.{{.Name}} = view.getUint16(offset{{GetTSLittleEndianArg .}});
   offset += 2;
   {{- else if eq .Type "uint32"}}
-  result.{{.Name}} = view.getUint32(offset, {{if eq .ByteOrder "little"}}true{{else}}false{{end}});
+  result.{{.Name}} = view.getUint32(offset{{GetTSLittleEndianArg .}});
   offset += 4;
   {{- else if eq .Type "int8"}}
   result.{{.Name}} = view.getInt8(offset);
   offset += 1;
   {{- else if eq .Type "int16"}}
-  result.{{.Name}} = view.getInt16(offset, {{if eq .ByteOrder "little"}}true{{else}}false{{end}});
+  result.{{.Name}} = view.getInt16(offset{{GetTSLittleEndianArg .}});
   offset += 2;
   {{- else if eq .Type "int32"}}
-  result.{{.Name}} = view.getInt32(offset, {{if eq .ByteOrder "little"}}true{{else}}false{{end}});
+  result.{{.Name}} = view.getInt32(offset{{GetTSLittleEndianArg .}});
   offset += 4;
+  {{- else if eq .Type "uint64"}}
+  result.{{.Name}} = view.getBigUint64(offset{{GetTSLittleEndianArg .}});
+  offset += 8;
+  {{- else if eq .Type "int64"}}
+  result.{{.Name}} = view.getBigInt64(offset{{GetTSLittleEndianArg .}});
+  offset += 8;
   {{- else if eq .Type "float"}}
-  result.{{.Name}} = view.getFloat32(offset, {{if eq .ByteOrder "little"}}true{{else}}false{{end}});
+  result.{{.Name}} = view.getFloat32(offset{{GetTSLittleEndianArg .}});
   offset += 4;
   {{- else if eq .Type "double"}}
-  result.{{.Name}} = view.getFloat64(offset, {{if eq .ByteOrder "little"}}true{{else}}false{{end}});
+  result.{{.Name}} = view.getFloat64(offset{{GetTSLittleEndianArg .}});
   offset += 8;
   {{- else if eq .Type "bool"}}
   result.{{.Name}} = view.getUint8(offset) !== 0;
   offset += 1;
   {{- else if eq .Type "string"}}
-  // String deserialization not fully implemented
-  result.{{.Name}} = "";
-  offset += 4; // Skip string length placeholder
+  {
+    {{- if eq .Encoding "prefixed"}}
+    // Length-prefixed string: {{.LengthType}} byte count followed by the bytes
+    const length = view.get{{GetDataViewType .LengthType}}(offset{{GetTSLengthEndianArg .}});
+    offset += {{GetTypeSizeC .LengthType}};
+    if (length > {{.MaxLength}}) {
+      throw new RangeError('{{.Name}} length ' + length + ' exceeds {{.MaxLength}} bytes');
+    }
+    result.{{.Name}} = new TextDecoder().decode(new Uint8Array(view.buffer, view.byteOffset + offset, length));
+    offset += length;
+    {{- else}}
+    // Fixed-capacity string: {{.MaxLength}} bytes padded with NUL
+    const field = new Uint8Array(view.buffer, view.byteOffset + offset, {{.MaxLength}});
+    const end = field.indexOf(0);
+    result.{{.Name}} = new TextDecoder().decode(end < 0 ? field : field.subarray(0, end));
+    offset += {{.MaxLength}};
+    {{- end}}
+  }
   {{- end}}
   {{- end}}
   {{- end}}
 
-  return result;
+  return [result, offset];
 }
 `))
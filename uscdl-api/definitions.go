@@ -0,0 +1,276 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/mail"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sammyjroberts/uscdl/compat"
+	"github.com/sammyjroberts/uscdl/model"
+)
+
+// DefinitionInfo describes a stored definition
+type DefinitionInfo struct {
+	Name string `json:"name"`
+	ETag string `json:"etag"`
+	Size int    `json:"size"`
+}
+
+// DefinitionResponse is returned by writes to a definition
+type DefinitionResponse struct {
+	Name string `json:"name,omitempty"`
+	ETag string `json:"etag,omitempty"`
+	// Problems lists the validation problems found in the definition. When
+	// any is an error the definition is not saved.
+	Problems []model.Problem `json:"problems"`
+	Error    string          `json:"error,omitempty"`
+}
+
+// DiffResponse is returned by GET /definitions/:name/diff
+type DiffResponse struct {
+	From     string          `json:"from"`
+	To       string          `json:"to"`
+	Breaking bool            `json:"breaking"`
+	Changes  []compat.Change `json:"changes"`
+}
+
+// definitionRoutes registers the definition CRUD and history endpoints.
+// Writes to an existing definition must carry an If-Match header with its
+// ETag, so that concurrent edits are detected instead of overwritten. Writes
+// may name their author in an X-Author header, as "Name <email>", and
+// describe the change in an X-Message header for the history.
+func definitionRoutes(e *echo.Echo, store *Store) {
+	e.GET("/definitions", listDefinitions(store))
+	e.GET("/definitions/:name", getDefinition(store))
+	e.POST("/definitions/:name", createDefinition(store))
+	e.PUT("/definitions/:name", updateDefinition(store))
+	e.DELETE("/definitions/:name", deleteDefinition(store))
+	e.GET("/definitions/:name/revisions", listRevisions(store))
+	e.GET("/definitions/:name/revisions/:revision", getRevision(store))
+	e.GET("/definitions/:name/diff", diffRevisions(store))
+}
+
+// requestChange returns the author and message of a write from the request
+// headers
+func requestChange(c echo.Context) Change {
+	change := Change{Message: c.Request().Header.Get("X-Message")}
+	author := c.Request().Header.Get("X-Author")
+	if address, err := mail.ParseAddress(author); err == nil {
+		change.Author, change.Email = address.Name, address.Address
+	} else {
+		change.Author = author
+	}
+	return change
+}
+
+// storeError answers a request that failed in the store
+func storeError(c echo.Context, err error) error {
+	status := http.StatusInternalServerError
+	response := DefinitionResponse{Problems: []model.Problem{}, Error: err.Error()}
+	var validationErr *model.ValidationError
+	switch {
+	case errors.Is(err, errInvalidName):
+		status = http.StatusBadRequest
+	case errors.Is(err, errNotFound), errors.Is(err, errUnknownRevision):
+		status = http.StatusNotFound
+	case errors.Is(err, errExists):
+		status = http.StatusConflict
+	case errors.Is(err, errConflict):
+		status = http.StatusPreconditionFailed
+	case errors.As(err, &validationErr):
+		status = http.StatusUnprocessableEntity
+		response.Problems = validationErr.Problems
+		response.Error = "validation failed"
+	}
+	return c.JSON(status, response)
+}
+
+// listDefinitions returns the name and ETag of every definition
+func listDefinitions(store *Store) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		definitions, err := store.List()
+		if err != nil {
+			return storeError(c, err)
+		}
+		list := []DefinitionInfo{}
+		for _, definition := range definitions {
+			list = append(list, DefinitionInfo{
+				Name: definition.Name,
+				ETag: definition.ETag,
+				Size: len(definition.Data),
+			})
+		}
+		return c.JSON(http.StatusOK, list)
+	}
+}
+
+// getDefinition returns a definition with its ETag, or 304 when it matches
+// If-None-Match
+func getDefinition(store *Store) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		definition, err := store.Get(c.Param("name"))
+		if err != nil {
+			return storeError(c, err)
+		}
+		c.Response().Header().Set("ETag", definition.ETag)
+		if c.Request().Header.Get("If-None-Match") == definition.ETag {
+			return c.NoContent(http.StatusNotModified)
+		}
+		return c.Blob(http.StatusOK, "application/json", definition.Data)
+	}
+}
+
+// createDefinition stores a new definition, failing with 409 if one with the
+// name exists
+func createDefinition(store *Store) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		body, err := readBody(c)
+		if err != nil {
+			return c.JSON(bodyStatus(err), DefinitionResponse{Problems: []model.Problem{}, Error: "failed to read request body: " + err.Error()})
+		}
+		definition, problems, err := store.Create(c.Param("name"), body, requestChange(c))
+		if err != nil {
+			return storeError(c, err)
+		}
+		return savedDefinition(c, http.StatusCreated, definition, problems)
+	}
+}
+
+// updateDefinition replaces a definition, failing with 428 without If-Match
+// and with 412 if the definition changed since the client read it
+func updateDefinition(store *Store) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ifMatch := c.Request().Header.Get("If-Match")
+		if ifMatch == "" {
+			return c.JSON(http.StatusPreconditionRequired, DefinitionResponse{Problems: []model.Problem{}, Error: "If-Match header required"})
+		}
+		body, err := readBody(c)
+		if err != nil {
+			return c.JSON(bodyStatus(err), DefinitionResponse{Problems: []model.Problem{}, Error: "failed to read request body: " + err.Error()})
+		}
+		definition, problems, err := store.Update(c.Param("name"), body, ifMatch, requestChange(c))
+		if err != nil {
+			return storeError(c, err)
+		}
+		return savedDefinition(c, http.StatusOK, definition, problems)
+	}
+}
+
+// deleteDefinition removes a definition, with the same preconditions as
+// updateDefinition
+func deleteDefinition(store *Store) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ifMatch := c.Request().Header.Get("If-Match")
+		if ifMatch == "" {
+			return c.JSON(http.StatusPreconditionRequired, DefinitionResponse{Problems: []model.Problem{}, Error: "If-Match header required"})
+		}
+		if err := store.Delete(c.Param("name"), ifMatch, requestChange(c)); err != nil {
+			return storeError(c, err)
+		}
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// savedDefinition answers a successful write with the new ETag and any
+// warnings
+func savedDefinition(c echo.Context, status int, definition *Definition, problems []model.Problem) error {
+	if problems == nil {
+		problems = []model.Problem{}
+	}
+	c.Response().Header().Set("ETag", definition.ETag)
+	return c.JSON(status, DefinitionResponse{
+		Name:     definition.Name,
+		ETag:     definition.ETag,
+		Problems: problems,
+	})
+}
+
+// listRevisions returns the recorded revisions of a definition, newest first
+func listRevisions(store *Store) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		revisions, err := store.Revisions(c.Param("name"))
+		if err != nil {
+			return storeError(c, err)
+		}
+		return c.JSON(http.StatusOK, revisions)
+	}
+}
+
+// getRevision returns a definition as it was at a revision
+func getRevision(store *Store) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		definition, err := store.GetRevision(c.Param("name"), c.Param("revision"))
+		if err != nil {
+			return storeError(c, err)
+		}
+		c.Response().Header().Set("ETag", definition.ETag)
+		return c.Blob(http.StatusOK, "application/json", definition.Data)
+	}
+}
+
+// diffRevisions compares a definition at the from revision with the to
+// revision, or the current definition when to is not given, and reports the
+// changes by whether they alter the wire layout
+func diffRevisions(store *Store) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		name := c.Param("name")
+		from, to := c.QueryParam("from"), c.QueryParam("to")
+		if from == "" {
+			return c.JSON(http.StatusBadRequest, DefinitionResponse{Problems: []model.Problem{}, Error: "from revision required"})
+		}
+
+		oldConfig, err := loadRevision(c, store, name, from)
+		if oldConfig == nil {
+			return err
+		}
+		newConfig, err := loadRevision(c, store, name, to)
+		if newConfig == nil {
+			return err
+		}
+		if to == "" {
+			to = "current"
+		}
+
+		changes := compat.Compare(oldConfig, newConfig)
+		if changes == nil {
+			changes = []compat.Change{}
+		}
+		return c.JSON(http.StatusOK, DiffResponse{
+			From:     from,
+			To:       to,
+			Breaking: compat.HasBreaking(changes),
+			Changes:  changes,
+		})
+	}
+}
+
+// loadRevision loads and validates a definition at a revision, or the current
+// definition for an empty revision. When that fails it answers the request
+// and returns a nil config.
+func loadRevision(c echo.Context, store *Store, name, revision string) (*model.Config, error) {
+	var definition *Definition
+	var err error
+	if revision == "" {
+		definition, err = store.Get(name)
+	} else {
+		definition, err = store.GetRevision(name, revision)
+	}
+	if err != nil {
+		return nil, storeError(c, err)
+	}
+
+	config, problems, err := model.Diagnose(name+".json", definition.Data, schemaFile)
+	if err != nil {
+		return nil, storeError(c, err)
+	}
+	if config == nil {
+		model.SortProblems(problems)
+		return nil, c.JSON(http.StatusUnprocessableEntity, DefinitionResponse{
+			Problems: problems,
+			Error:    fmt.Sprintf("revision %s is not a valid definition", revision),
+		})
+	}
+	return config, nil
+}
@@ -0,0 +1,286 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sammyjroberts/uscdl/generate"
+	"github.com/sammyjroberts/uscdl/model"
+)
+
+// generatedDir is where generated files are written and served from
+const generatedDir = "../generated"
+
+// schemaFile is the JSON Schema definitions are validated against
+const schemaFile = "../schema.json"
+
+// maxBodySize is the largest request body accepted, far above any real
+// definition
+const maxBodySize = 1 << 20
+
+// FileInfo describes a file in the generated directory
+type FileInfo struct {
+	// Name is the slash-separated path below the generated directory
+	Name string `json:"name"`
+	// Type is the file extension without the dot, such as "c", "h" or "ts"
+	Type string `json:"type"`
+	Size int64  `json:"size"`
+}
+
+// GenerateResponse is returned by POST /generate
+type GenerateResponse struct {
+	// Files maps the slash-separated name of each generated file to its
+	// contents
+	Files map[string]string `json:"files,omitempty"`
+	// Problems lists the validation problems found in the definition. When
+	// any is an error nothing is generated.
+	Problems []model.Problem `json:"problems"`
+	Error    string          `json:"error,omitempty"`
+}
+
+// definitionRequest is a POST /generate or /bundle request whose definition
+// passed validation
+type definitionRequest struct {
+	config   *model.Config
+	problems []model.Problem
+	backends []string
+	opts     generate.Options
+}
+
+// requestError is a failed request with the status and response to answer it
+// with
+type requestError struct {
+	status   int
+	response GenerateResponse
+}
+
+// failRequest returns a requestError with a message and the problems found
+func failRequest(status int, problems []model.Problem, message string) *requestError {
+	if problems == nil {
+		problems = []model.Problem{}
+	}
+	return &requestError{status, GenerateResponse{Problems: problems, Error: message}}
+}
+
+// readDefinition parses the backends and module query parameters and
+// validates the definition in the request body. Invalid definitions fail with
+// 422 and the problems found.
+func readDefinition(c echo.Context) (*definitionRequest, *requestError) {
+	backends, err := queryBackends(c.QueryParam("backends"))
+	if err != nil {
+		return nil, failRequest(http.StatusBadRequest, nil, err.Error())
+	}
+
+	body, err := readBody(c)
+	if err != nil {
+		return nil, failRequest(bodyStatus(err), nil, "failed to read request body: "+err.Error())
+	}
+	config, problems, err := model.Diagnose("", body, schemaFile)
+	if err != nil {
+		return nil, failRequest(http.StatusInternalServerError, nil, err.Error())
+	}
+	if problems == nil {
+		problems = []model.Problem{}
+	}
+	model.SortProblems(problems)
+	if model.HasErrors(problems) {
+		return nil, failRequest(http.StatusUnprocessableEntity, problems, "validation failed")
+	}
+
+	return &definitionRequest{
+		config:   config,
+		problems: problems,
+		backends: backends,
+		opts:     generate.Options{Module: generate.ModuleName(c.QueryParam("module"))},
+	}, nil
+}
+
+// readBody reads the request body, failing with an *http.MaxBytesError when
+// it is larger than maxBodySize
+func readBody(c echo.Context) ([]byte, error) {
+	return io.ReadAll(http.MaxBytesReader(c.Response(), c.Request().Body, maxBodySize))
+}
+
+// bodyStatus returns the status to answer a failure of readBody with
+func bodyStatus(err error) int {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return http.StatusRequestEntityTooLarge
+	}
+	return http.StatusBadRequest
+}
+
+// generateCode validates the definition in the request body and generates the
+// selected backends in memory, returning the files without touching the
+// disk. Query parameters:
+//
+//   - backends: comma-separated backends, defaulting to every backend
+//   - module: the Go package and the Python and Rust module name
+//   - format: json, the default, for a map of file names to contents, or zip
+//     for a ZIP archive of the files
+//   - write: true to also write the files to the generated directory
+//
+// Invalid definitions are answered with 422 and the problems found.
+func generateCode(c echo.Context) error {
+	format := c.QueryParam("format")
+	if format != "" && format != "json" && format != "zip" {
+		fail := failRequest(http.StatusBadRequest, nil, fmt.Sprintf("unknown format %q", format))
+		return c.JSON(fail.status, fail.response)
+	}
+	req, fail := readDefinition(c)
+	if fail != nil {
+		return c.JSON(fail.status, fail.response)
+	}
+
+	files := generate.MapFS{}
+	if err := generate.Generate(req.config, req.backends, files, req.opts); err != nil {
+		fail := failRequest(http.StatusInternalServerError, req.problems, err.Error())
+		return c.JSON(fail.status, fail.response)
+	}
+	if c.QueryParam("write") == "true" {
+		for _, name := range files.Names() {
+			if err := generate.DirFS(generatedDir).WriteFile(name, files[name]); err != nil {
+				fail := failRequest(http.StatusInternalServerError, req.problems, err.Error())
+				return c.JSON(fail.status, fail.response)
+			}
+		}
+	}
+
+	if format == "zip" {
+		return sendArchive(c, files, "zip", req.opts.Module+".zip")
+	}
+	contents := make(map[string]string, len(files))
+	for name, data := range files {
+		contents[name] = string(data)
+	}
+	return c.JSON(http.StatusOK, GenerateResponse{Files: contents, Problems: req.problems})
+}
+
+// bundleCode validates the definition in the request body and answers with a
+// bundle of the selected backends, each in a subdirectory, and a manifest.
+// It takes the backends and module query parameters of generateCode, and
+// format for the archive format, zip by default or tar.gz.
+func bundleCode(c echo.Context) error {
+	format := c.QueryParam("format")
+	if format == "" {
+		format = "zip"
+	}
+	if !slices.Contains(generate.ArchiveFormats, format) {
+		fail := failRequest(http.StatusBadRequest, nil, fmt.Sprintf("unknown format %q", format))
+		return c.JSON(fail.status, fail.response)
+	}
+	req, fail := readDefinition(c)
+	if fail != nil {
+		return c.JSON(fail.status, fail.response)
+	}
+
+	files, err := generate.Bundle(req.config, req.backends, req.opts)
+	if err != nil {
+		fail := failRequest(http.StatusInternalServerError, req.problems, err.Error())
+		return c.JSON(fail.status, fail.response)
+	}
+	return sendArchive(c, files, format, req.opts.Module+"."+format)
+}
+
+// sendArchive answers with an archive of files as a download
+func sendArchive(c echo.Context, files generate.MapFS, format, filename string) error {
+	contentType := "application/zip"
+	if format == "tar.gz" {
+		contentType = "application/gzip"
+	}
+	c.Response().Header().Set(echo.HeaderContentType, contentType)
+	c.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="`+filename+`"`)
+	c.Response().WriteHeader(http.StatusOK)
+	return files.WriteArchive(c.Response(), format)
+}
+
+// queryBackends parses a comma-separated backend list, where an empty list
+// selects every backend
+func queryBackends(list string) ([]string, error) {
+	var backends []string
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if _, ok := generate.Lookup(name); !ok {
+			return nil, fmt.Errorf("unknown backend %q", name)
+		}
+		backends = append(backends, name)
+	}
+	return backends, nil
+}
+
+// fileType returns the extension of a file name without the dot
+func fileType(name string) string {
+	return strings.TrimPrefix(strings.ToLower(path.Ext(name)), ".")
+}
+
+// getGeneratedFiles returns information about all files in the generated
+// directory, including those in backend subdirectories
+func getGeneratedFiles(c echo.Context) error {
+	fileList := []FileInfo{}
+	err := filepath.WalkDir(generatedDir, func(file string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			// Skip caches such as Python's __pycache__
+			if strings.HasPrefix(entry.Name(), "__") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil
+		}
+		name, err := filepath.Rel(generatedDir, file)
+		if err != nil {
+			return err
+		}
+		name = filepath.ToSlash(name)
+		fileList = append(fileList, FileInfo{
+			Name: name,
+			Type: fileType(name),
+			Size: info.Size(),
+		})
+		return nil
+	})
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to read generated directory: " + err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, fileList)
+}
+
+// serveGeneratedFile serves a specific file from the generated directory
+func serveGeneratedFile(c echo.Context) error {
+	filename := c.Param("*")
+
+	// Basic security check - don't allow path traversal
+	if strings.Contains(filename, "..") {
+		return c.String(http.StatusBadRequest, "Invalid filename")
+	}
+
+	data, err := os.ReadFile(filepath.Join(generatedDir, filepath.FromSlash(filename)))
+	if err != nil {
+		return c.String(http.StatusNotFound, "File not found")
+	}
+
+	contentType := "text/plain"
+	if strings.HasSuffix(filename, ".json") {
+		contentType = "application/json"
+	}
+
+	return c.Blob(http.StatusOK, contentType, data)
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// errUnknownRevision is returned for revisions that do not exist or do not
+// contain the definition
+var errUnknownRevision = errors.New("unknown revision")
+
+// revisionID matches abbreviated and full commit hashes, which keeps
+// revisions from being taken as git options or revision expressions
+var revisionID = regexp.MustCompile(`^[0-9a-f]{4,40}$`)
+
+// committer records changes made through the API
+const (
+	committerName  = "uscdl-api"
+	committerEmail = "uscdl-api@localhost"
+)
+
+// Change describes who made a change to a definition and why
+type Change struct {
+	Author  string
+	Email   string
+	Message string
+}
+
+// Revision is a recorded version of a definition
+type Revision struct {
+	ID      string    `json:"id"`
+	Author  string    `json:"author"`
+	Email   string    `json:"email"`
+	Date    time.Time `json:"date"`
+	Message string    `json:"message"`
+}
+
+// History records every change to the definitions directory in a git
+// repository, using the git command line. The repository is kept apart from
+// the directory, so the directory can itself live in another work tree.
+type History struct {
+	gitDir   string
+	workTree string
+}
+
+// OpenHistory opens the git repository at gitDir that tracks the definitions
+// in workTree. A missing repository is created with a first commit of the
+// definitions already there.
+func OpenHistory(gitDir, workTree string) (*History, error) {
+	gitDir, err := filepath.Abs(gitDir)
+	if err != nil {
+		return nil, err
+	}
+	workTree, err = filepath.Abs(workTree)
+	if err != nil {
+		return nil, err
+	}
+	h := &History{gitDir: gitDir, workTree: workTree}
+
+	if _, err := os.Stat(gitDir); err == nil {
+		return h, nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to open history: %w", err)
+	}
+	if _, err := h.git("init", "--quiet"); err != nil {
+		return nil, fmt.Errorf("failed to create history: %w", err)
+	}
+	// Leave out the temporary files of writes in progress
+	if err := os.WriteFile(filepath.Join(gitDir, "info", "exclude"), []byte(".*.tmp\n"), 0644); err != nil {
+		return nil, fmt.Errorf("failed to create history: %w", err)
+	}
+	if err := h.Commit(".", Change{Author: committerName, Email: committerEmail, Message: "Import existing definitions"}); err != nil {
+		return nil, fmt.Errorf("failed to create history: %w", err)
+	}
+	return h, nil
+}
+
+// git runs a git command on the history repository and returns its output
+func (h *History) git(command string, args ...string) ([]byte, error) {
+	return h.gitEnv(nil, command, args...)
+}
+
+// gitEnv runs a git command with extra environment variables
+func (h *History) gitEnv(env []string, command string, args ...string) ([]byte, error) {
+	// Keep user configuration such as commit signing out of the way
+	args = append([]string{"-c", "commit.gpgsign=false", "-c", "core.autocrlf=false", command}, args...)
+	cmd := exec.Command("git", args...)
+	cmd.Dir = h.workTree
+	cmd.Env = append(os.Environ(), "GIT_DIR="+h.gitDir, "GIT_WORK_TREE="+h.workTree)
+	cmd.Env = append(cmd.Env, env...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git %s: %w: %s", command, err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
+
+// Commit records the current state of the files matching a pathspec, added,
+// changed or removed. Nothing is recorded when they did not change. When the
+// commit fails the files are unstaged again, so that the next commit does not
+// pick up the failed change.
+func (h *History) Commit(pathspec string, change Change) error {
+	if _, err := h.git("add", "--all", "--", pathspec); err != nil {
+		return err
+	}
+	status, err := h.git("status", "--porcelain", "--", pathspec)
+	if err != nil {
+		return err
+	}
+	if len(status) == 0 {
+		return nil
+	}
+	if change.Author == "" {
+		change.Author = committerName
+	}
+	if change.Email == "" {
+		change.Email = committerEmail
+	}
+	env := []string{
+		"GIT_AUTHOR_NAME=" + change.Author,
+		"GIT_AUTHOR_EMAIL=" + change.Email,
+		"GIT_COMMITTER_NAME=" + committerName,
+		"GIT_COMMITTER_EMAIL=" + committerEmail,
+	}
+	if _, err := h.gitEnv(env, "commit", "--quiet", "--message", change.Message, "--", pathspec); err != nil {
+		h.unstage(pathspec)
+		return err
+	}
+	return nil
+}
+
+// unstage resets the index of the files matching a pathspec to the last
+// commit, or empties it when there is none yet
+func (h *History) unstage(pathspec string) {
+	if _, err := h.git("rev-parse", "--verify", "--quiet", "HEAD"); err != nil {
+		h.git("rm", "--cached", "-r", "--quiet", "--ignore-unmatch", "--", pathspec)
+		return
+	}
+	h.git("reset", "--quiet", "--", pathspec)
+}
+
+// Revisions returns the revisions of a file, newest first
+func (h *History) Revisions(file string) ([]Revision, error) {
+	revisions := []Revision{}
+	// A history that started from an empty directory has no commits until
+	// the first save, and git log fails on it
+	if _, err := h.git("rev-parse", "--verify", "--quiet", "HEAD"); err != nil {
+		return revisions, nil
+	}
+	out, err := h.git("log", "--format=%H%x00%an%x00%ae%x00%aI%x00%s", "--", file)
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		fields := strings.Split(line, "\x00")
+		if len(fields) != 5 {
+			continue
+		}
+		date, err := time.Parse(time.RFC3339, fields[3])
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse revision date: %w", err)
+		}
+		revisions = append(revisions, Revision{
+			ID:      fields[0],
+			Author:  fields[1],
+			Email:   fields[2],
+			Date:    date,
+			Message: fields[4],
+		})
+	}
+	return revisions, nil
+}
+
+// Show returns the contents of a file at a revision
+func (h *History) Show(file, revision string) ([]byte, error) {
+	if !revisionID.MatchString(revision) {
+		return nil, errUnknownRevision
+	}
+	out, err := h.git("show", revision+":"+file)
+	if err != nil {
+		return nil, errUnknownRevision
+	}
+	return out, nil
+}
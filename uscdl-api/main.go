@@ -1,34 +1,57 @@
 package main
 
 import (
+	"flag"
+	"log"
 	"net/http"
 	"os"
-	"path/filepath"
-	"strings"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 )
 
 func main() {
+	definitionsDir := flag.String("definitions", "../definitions", "directory of stored subsystem definitions")
+	historyDir := flag.String("history", "../definitions.git", "git repository recording every change to the definitions, or empty for none")
+	flag.Parse()
+
+	var history *History
+	if *historyDir != "" {
+		if err := os.MkdirAll(*definitionsDir, 0755); err != nil {
+			log.Fatal(err)
+		}
+		h, err := OpenHistory(*historyDir, *definitionsDir)
+		if err != nil {
+			log.Fatal(err)
+		}
+		history = h
+	}
+	store, err := NewStore(*definitionsDir, history)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	// Create a new Echo instance
 	e := echo.New()
 
 	// Add middleware
 	e.Use(middleware.Logger())
 	e.Use(middleware.Recover())
-	e.Use(middleware.CORS()) // Enable CORS for frontend access
+	// Enable CORS for frontend access, letting it read ETags for saving
+	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
+		ExposeHeaders: []string{"ETag"},
+	}))
 
 	// Define routes
 	e.GET("/schema.json", serveFile("../schema.json"))
-	e.GET("/adcs.json", serveFile("../adcs.json"))
-	e.GET("/eps.json", serveFile("../eps.json"))
+	definitionRoutes(e, store)
 
 	// Add route to get all generated files
 	e.GET("/generated", getGeneratedFiles)
-	e.GET("/generated/:filename", serveGeneratedFile)
-	// Add API endpoint to generate code (future extension)
+	e.GET("/generated/*", serveGeneratedFile)
+	// Add API endpoint to validate a definition and generate its code
 	e.POST("/generate", generateCode)
+	e.POST("/bundle", bundleCode)
 
 	// Start the server
 	e.Logger.Fatal(e.Start(":8080"))
@@ -46,77 +69,3 @@ func serveFile(filename string) echo.HandlerFunc {
 		return c.Blob(http.StatusOK, "application/json", data)
 	}
 }
-
-// generateCode is a placeholder for future code generation functionality
-func generateCode(c echo.Context) error {
-	return c.JSON(http.StatusOK, map[string]string{
-		"status": "Not implemented yet",
-	})
-}
-
-// getGeneratedFiles returns information about all files in the generated directory
-func getGeneratedFiles(c echo.Context) error {
-	files, err := os.ReadDir("../generated")
-	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to read generated directory: " + err.Error(),
-		})
-	}
-
-	type FileInfo struct {
-		Name string `json:"name"`
-		Type string `json:"type"` // "c", "h", or "ts"
-		Size int64  `json:"size"`
-	}
-
-	var fileList []FileInfo
-	for _, file := range files {
-		if file.IsDir() {
-			continue
-		}
-
-		ext := strings.ToLower(filepath.Ext(file.Name()))
-		fileType := ""
-		switch ext {
-		case ".c":
-			fileType = "c"
-		case ".h":
-			fileType = "h"
-		case ".ts":
-			fileType = "ts"
-		default:
-			continue // Skip files with unsupported extensions
-		}
-
-		fileList = append(fileList, FileInfo{
-			Name: file.Name(),
-			Type: fileType,
-			Size: file.Size(),
-		})
-	}
-
-	return c.JSON(http.StatusOK, fileList)
-}
-
-// serveGeneratedFile serves a specific file from the generated directory
-func serveGeneratedFile(c echo.Context) error {
-	filename := c.Param("filename")
-
-	// Basic security check - don't allow path traversal
-	if strings.Contains(filename, "..") {
-		return c.String(http.StatusBadRequest, "Invalid filename")
-	}
-
-	filepath := filepath.Join("../generated", filename)
-	data, err := os.ReadFile(filepath)
-	if err != nil {
-		return c.String(http.StatusNotFound, "File not found")
-	}
-
-	contentType := "text/plain"
-	if strings.HasSuffix(filename, ".json") {
-		contentType = "application/json"
-	}
-
-	return c.Blob(http.StatusOK, contentType, data)
-}
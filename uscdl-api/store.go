@@ -0,0 +1,300 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/sammyjroberts/uscdl/model"
+)
+
+// Errors returned by the store
+var (
+	errInvalidName = errors.New("definition names must be lowercase letters, digits, - and _")
+	errNotFound    = errors.New("definition not found")
+	errExists      = errors.New("definition already exists")
+	errConflict    = errors.New("definition was changed by someone else")
+)
+
+// definitionName matches the names of stored definitions, which become file
+// and module names
+var definitionName = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// Definition is a stored subsystem definition
+type Definition struct {
+	Name string
+	Data []byte
+	// ETag identifies the contents of the definition, quoted for use in
+	// HTTP headers
+	ETag string
+}
+
+// Store keeps subsystem definitions as <name>.json files in a directory.
+// Every write is validated, so the store only holds definitions that
+// generate, and none defines an enum or container another one does. Writes
+// are recorded in the history when there is one.
+type Store struct {
+	dir     string
+	history *History
+	// mu serializes writes so that precondition checks and validation see
+	// the definitions they replace
+	mu sync.Mutex
+}
+
+// NewStore opens a store in a directory, creating it if needed. A nil
+// history records nothing.
+func NewStore(dir string, history *History) (*Store, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create definitions directory: %w", err)
+	}
+	return &Store{dir: dir, history: history}, nil
+}
+
+// etag returns the quoted entity tag of definition contents
+func etag(data []byte) string {
+	sum := sha256.Sum256(data)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// path returns the file of a definition
+func (s *Store) path(name string) string {
+	return filepath.Join(s.dir, name+".json")
+}
+
+// List returns every stored definition in name order
+func (s *Store) List() ([]Definition, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read definitions directory: %w", err)
+	}
+	var definitions []Definition
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".json")
+		if !ok || entry.IsDir() || !definitionName.MatchString(name) {
+			continue
+		}
+		definition, err := s.Get(name)
+		if err != nil {
+			return nil, err
+		}
+		definitions = append(definitions, *definition)
+	}
+	sort.Slice(definitions, func(i, j int) bool {
+		return definitions[i].Name < definitions[j].Name
+	})
+	return definitions, nil
+}
+
+// Get returns a stored definition
+func (s *Store) Get(name string) (*Definition, error) {
+	if !definitionName.MatchString(name) {
+		return nil, errInvalidName
+	}
+	data, err := os.ReadFile(s.path(name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, errNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read definition %s: %w", name, err)
+	}
+	return &Definition{Name: name, Data: data, ETag: etag(data)}, nil
+}
+
+// Create stores a new definition. It returns the problems found by
+// validation, and a *model.ValidationError when any is an error.
+func (s *Store) Create(name string, data []byte, change Change) (*Definition, []model.Problem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.Get(name); err == nil {
+		return nil, nil, errExists
+	} else if !errors.Is(err, errNotFound) {
+		return nil, nil, err
+	}
+	if change.Message == "" {
+		change.Message = "Create " + name
+	}
+	return s.write(name, data, change)
+}
+
+// Update replaces a definition if its ETag matches ifMatch, which may also be
+// * to match any version. It returns the problems found by validation, and a
+// *model.ValidationError when any is an error.
+func (s *Store) Update(name string, data []byte, ifMatch string, change Change) (*Definition, []model.Problem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.match(name, ifMatch); err != nil {
+		return nil, nil, err
+	}
+	if change.Message == "" {
+		change.Message = "Update " + name
+	}
+	return s.write(name, data, change)
+}
+
+// Delete removes a definition if its ETag matches ifMatch, which may also be
+// * to match any version
+func (s *Store) Delete(name, ifMatch string, change Change) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.match(name, ifMatch); err != nil {
+		return err
+	}
+	previous, err := s.Get(name)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(s.path(name)); err != nil {
+		return fmt.Errorf("failed to delete definition %s: %w", name, err)
+	}
+	if change.Message == "" {
+		change.Message = "Delete " + name
+	}
+	return s.record(name, previous, change)
+}
+
+// record commits a change to a definition to the history. When that fails
+// the previous definition, or nil if there was none, is put back, so that
+// the store never holds a change the history is missing.
+func (s *Store) record(name string, previous *Definition, change Change) error {
+	if s.history == nil {
+		return nil
+	}
+	err := s.history.Commit(name+".json", change)
+	if err == nil {
+		return nil
+	}
+	if previous != nil {
+		err = errors.Join(err, s.writeFile(name, previous.Data))
+	} else if removeErr := os.Remove(s.path(name)); removeErr != nil && !errors.Is(removeErr, os.ErrNotExist) {
+		err = errors.Join(err, removeErr)
+	}
+	return fmt.Errorf("failed to record change to %s: %w", name, err)
+}
+
+// Revisions returns the recorded revisions of a definition, newest first
+func (s *Store) Revisions(name string) ([]Revision, error) {
+	if !definitionName.MatchString(name) {
+		return nil, errInvalidName
+	}
+	if s.history == nil {
+		return []Revision{}, nil
+	}
+	return s.history.Revisions(name + ".json")
+}
+
+// GetRevision returns a definition as it was at a revision
+func (s *Store) GetRevision(name, revision string) (*Definition, error) {
+	if !definitionName.MatchString(name) {
+		return nil, errInvalidName
+	}
+	if s.history == nil {
+		return nil, errUnknownRevision
+	}
+	data, err := s.history.Show(name+".json", revision)
+	if err != nil {
+		return nil, err
+	}
+	return &Definition{Name: name, Data: data, ETag: etag(data)}, nil
+}
+
+// match checks that a definition exists and that its ETag matches an If-Match
+// header value, which may list several tags
+func (s *Store) match(name, ifMatch string) error {
+	current, err := s.Get(name)
+	if err != nil {
+		return err
+	}
+	for _, tag := range strings.Split(ifMatch, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || tag == current.ETag {
+			return nil
+		}
+	}
+	return errConflict
+}
+
+// write validates a definition against the schema, the semantic rules and
+// the other stored definitions, then writes and records it
+func (s *Store) write(name string, data []byte, change Change) (*Definition, []model.Problem, error) {
+	config, problems, err := model.Diagnose(name+".json", data, schemaFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	if config != nil {
+		var configs []*model.Config
+		others, err := s.List()
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, other := range others {
+			if other.Name == name {
+				continue
+			}
+			// Stored definitions were valid when written, but the schema
+			// may have changed since
+			if otherConfig, _, err := model.Diagnose(other.Name+".json", other.Data, schemaFile); err == nil && otherConfig != nil {
+				configs = append(configs, otherConfig)
+			}
+		}
+		// Duplicates are reported against the later definition
+		configs = append(configs, config)
+		for _, problem := range model.CheckDuplicates(configs) {
+			if problem.File == name+".json" {
+				problems = append(problems, problem)
+			}
+		}
+	}
+	model.SortProblems(problems)
+	if model.HasErrors(problems) {
+		return nil, problems, &model.ValidationError{Problems: problems}
+	}
+
+	previous, err := s.Get(name)
+	if errors.Is(err, errNotFound) {
+		previous = nil
+	} else if err != nil {
+		return nil, nil, err
+	}
+	if err := s.writeFile(name, data); err != nil {
+		return nil, nil, err
+	}
+	if err := s.record(name, previous, change); err != nil {
+		return nil, nil, err
+	}
+	return &Definition{Name: name, Data: data, ETag: etag(data)}, problems, nil
+}
+
+// writeFile replaces the file of a definition. It writes to a temporary file
+// first so that readers never see a partial definition.
+func (s *Store) writeFile(name string, data []byte) error {
+	temp, err := os.CreateTemp(s.dir, "."+name+"-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write definition %s: %w", name, err)
+	}
+	defer os.Remove(temp.Name())
+	if err := temp.Chmod(0644); err != nil {
+		temp.Close()
+		return fmt.Errorf("failed to write definition %s: %w", name, err)
+	}
+	if _, err := temp.Write(data); err != nil {
+		temp.Close()
+		return fmt.Errorf("failed to write definition %s: %w", name, err)
+	}
+	if err := temp.Close(); err != nil {
+		return fmt.Errorf("failed to write definition %s: %w", name, err)
+	}
+	if err := os.Rename(temp.Name(), s.path(name)); err != nil {
+		return fmt.Errorf("failed to write definition %s: %w", name, err)
+	}
+	return nil
+}
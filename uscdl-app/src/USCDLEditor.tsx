@@ -6,7 +6,11 @@ import { monokai } from 'react-syntax-highlighter/dist/esm/styles/hljs';
 
 function USCDLEditor() {
   const [schema, setSchema] = useState(null);
+  // The stored definitions, and the name of the one being edited
+  const [definitions, setDefinitions] = useState(null);
+  const [definitionName, setDefinitionName] = useState(null);
   const [data, setData] = useState(null);
+  const [etag, setEtag] = useState(null);
   const [generatedFiles, setGeneratedFiles] = useState([]);
   const [selectedFile, setSelectedFile] = useState(null);
   const [fileContent, setFileContent] = useState('');
@@ -29,44 +33,74 @@ function USCDLEditor() {
       })
       .catch(error => console.error('Error loading schema:', error));
 
-    // Load sample data
-    fetch('http://localhost:8080/adcs.json')
+    // List the stored definitions and start with the first
+    fetch('http://localhost:8080/definitions')
       .then(response => response.json())
-      .then(jsonData => {
-        setData(jsonData);
+      .then(list => {
+        setDefinitions(list);
+        if (list.length > 0) {
+          setDefinitionName(list[0].name);
+        }
       })
-      .catch(error => console.error('Error loading data:', error));
-
-    // Load generated files
-    fetchGeneratedFiles();
+      .catch(error => console.error('Error listing definitions:', error));
   }, []);
 
-  const fetchGeneratedFiles = () => {
-    fetch('http://localhost:8080/generated')
-      .then(response => response.json())
-      .then(files => {
-        setGeneratedFiles(files);
-        if (files.length > 0 && !selectedFile) {
-          setSelectedFile(files[0]);
-          fetchFileContent(files[0].name);
-        }
+  useEffect(() => {
+    if (!definitionName) return;
+    // Load the selected definition from the store
+    setData(null);
+    setGeneratedFiles([]);
+    setSelectedFile(null);
+    fetch(`http://localhost:8080/definitions/${definitionName}`)
+      .then(response => {
+        setEtag(response.headers.get('ETag'));
+        return response.json();
       })
-      .catch(error => console.error('Error loading generated files:', error));
-  };
-
-  const fetchFileContent = (filename) => {
-    fetch(`http://localhost:8080/generated/${filename}`)
-      .then(response => response.text())
-      .then(content => {
-        setFileContent(content);
+      .then(jsonData => {
+        setData(jsonData);
       })
-      .catch(error => console.error(`Error loading file ${filename}:`, error));
+      .catch(error => console.error('Error loading data:', error));
+  }, [definitionName]);
+
+  const selectFile = (file) => {
+    setSelectedFile(file);
+    setFileContent(file.content);
   };
 
   const handleFormChange = ({ data: newData }) => {
     setData(newData);
   };
 
+  const saveDefinition = () => {
+    fetch(`http://localhost:8080/definitions/${definitionName}`, {
+      method: 'PUT',
+      headers: {
+        'Content-Type': 'application/json',
+        'If-Match': etag,
+      },
+      body: JSON.stringify(data, null, 2) + '\n',
+    })
+      .then(response => response.json().then(result => ({ status: response.status, result })))
+      .then(({ status, result }) => {
+        if (status === 412) {
+          alert('The definition was changed by someone else. Reload it before saving.');
+          return;
+        }
+        if (result.error) {
+          const problems = result.problems
+            .map(problem => `${problem.severity}: ${problem.message} (at ${problem.path})`)
+            .join('\n');
+          alert(`Failed to save: ${result.error}\n${problems}`);
+          return;
+        }
+        setEtag(result.etag);
+      })
+      .catch(error => {
+        console.error('Error saving definition:', error);
+        alert('Failed to save definition. See console for details.');
+      });
+  };
+
   const downloadJson = () => {
     const jsonString = JSON.stringify(data, null, 2);
     const blob = new Blob([jsonString], { type: 'application/json' });
@@ -91,8 +125,23 @@ function USCDLEditor() {
     })
       .then(response => response.json())
       .then(result => {
-        alert('Code generation successful!');
-        fetchGeneratedFiles(); // Refresh the file list
+        if (result.error) {
+          const problems = result.problems
+            .map(problem => `${problem.severity}: ${problem.message} (at ${problem.path})`)
+            .join('\n');
+          alert(`Code generation failed: ${result.error}\n${problems}`);
+          return;
+        }
+        // The server returns the files instead of storing them
+        const files = Object.keys(result.files).sort().map(name => ({
+          name,
+          type: name.split('.').pop(),
+          content: result.files[name],
+        }));
+        setGeneratedFiles(files);
+        if (files.length > 0) {
+          selectFile(files[0]);
+        }
       })
       .catch(error => {
         console.error('Error generating code:', error);
@@ -100,14 +149,54 @@ function USCDLEditor() {
       });
   };
 
+  const downloadBundle = () => {
+    fetch('http://localhost:8080/bundle?format=zip', {
+      method: 'POST',
+      headers: {
+        'Content-Type': 'application/json',
+      },
+      body: JSON.stringify(data),
+    })
+      .then(response => {
+        if (!response.ok) {
+          return response.json().then(result => {
+            throw new Error(result.error);
+          });
+        }
+        return response.blob();
+      })
+      .then(blob => {
+        const url = URL.createObjectURL(blob);
+        const a = document.createElement('a');
+        a.href = url;
+        a.download = 'uscdl.zip';
+        document.body.appendChild(a);
+        a.click();
+        document.body.removeChild(a);
+        URL.revokeObjectURL(url);
+      })
+      .catch(error => {
+        console.error('Error downloading bundle:', error);
+        alert(`Failed to download bundle: ${error.message}`);
+      });
+  };
+
   const getLanguage = (file) => {
     if (!file) return 'text';
     if (file.type === 'c') return 'c';
     if (file.type === 'h') return 'cpp'; // C headers can use C++ highlighting
     if (file.type === 'ts') return 'typescript';
+    if (file.type === 'go') return 'go';
+    if (file.type === 'py') return 'python';
+    if (file.type === 'rs') return 'rust';
+    if (file.type === 'md') return 'markdown';
     return 'text';
   };
 
+  if (definitions && definitions.length === 0) {
+    return <div className="container mt-5"><div className="alert alert-info">No definitions are stored yet.</div></div>;
+  }
+
   if (!schema || !data) {
     return <div className="container mt-5"><div className="alert alert-info">Loading schema and data...</div></div>;
   }
@@ -117,6 +206,17 @@ function USCDLEditor() {
       <div className="row mb-4">
         <div className="col-12">
           <h1 className="my-4">Spacecraft Data Definition Editor</h1>
+          <select
+            className="form-select w-auto"
+            value={definitionName}
+            onChange={event => setDefinitionName(event.target.value)}
+          >
+            {definitions.map(definition => (
+              <option key={definition.name} value={definition.name}>
+                {definition.name}
+              </option>
+            ))}
+          </select>
         </div>
       </div>
 
@@ -141,12 +241,18 @@ function USCDLEditor() {
       <div className="row mb-4">
         <div className="col-12">
           <div className="btn-group">
-            <button onClick={downloadJson} className="btn btn-primary">
+            <button onClick={saveDefinition} className="btn btn-primary">
+              Save
+            </button>
+            <button onClick={downloadJson} className="btn btn-primary ms-2">
               Download JSON
             </button>
             <button onClick={generateCode} className="btn btn-success ms-2">
               Generate Code
             </button>
+            <button onClick={downloadBundle} className="btn btn-secondary ms-2">
+              Download Bundle
+            </button>
           </div>
         </div>
       </div>
@@ -177,10 +283,7 @@ function USCDLEditor() {
                         <button
                           key={file.name}
                           className={`list-group-item list-group-item-action ${selectedFile && selectedFile.name === file.name ? 'active' : ''}`}
-                          onClick={() => {
-                            setSelectedFile(file);
-                            fetchFileContent(file.name);
-                          }}
+                          onClick={() => selectFile(file)}
                         >
                           {file.name}
                         </button>
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/sammyjroberts/uscdl/model"
+)
+
+// runValidate implements the validate subcommand, checking configs against
+// the schema and the semantic rules without generating anything. Every
+// problem in every file is reported, including enums and containers defined
+// in more than one file.
+func runValidate(args []string) error {
+	flags := newFlagSet("validate", "<config.json>...")
+	schemaFile := flags.String("schema", "schema.json", "JSON Schema used to validate the configs")
+	format := flags.String("format", "text", "output format: text, json or sarif")
+	quiet := flags.Bool("q", false, "print nothing but problems in text format")
+	if err := parseFlags(flags, args, 1, -1); err != nil {
+		return err
+	}
+	if *format != "text" && *format != "json" && *format != "sarif" {
+		return fmt.Errorf("%w: unknown format %q", errUsage, *format)
+	}
+
+	var problems []model.Problem
+	var configs []*model.Config
+	for _, file := range flags.Args() {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to read config file: %w", err)
+		}
+		config, found, err := model.Diagnose(file, data, *schemaFile)
+		if err != nil {
+			return err
+		}
+		problems = append(problems, found...)
+		if config != nil {
+			configs = append(configs, config)
+			if *format == "text" && !*quiet {
+				fmt.Printf("%s is valid: %d enums, %d containers\n", file, len(config.Enums), len(config.Containers))
+			}
+		}
+	}
+	problems = append(problems, model.CheckDuplicates(configs)...)
+	model.SortProblems(problems)
+
+	switch *format {
+	case "json":
+		if problems == nil {
+			problems = []model.Problem{}
+		}
+		out, err := json.MarshalIndent(problems, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(out))
+	case "sarif":
+		if err := writeSARIF(os.Stdout, problems); err != nil {
+			return err
+		}
+	default:
+		for _, problem := range problems {
+			fmt.Fprintln(os.Stderr, problem)
+		}
+	}
+
+	if model.HasErrors(problems) {
+		return fmt.Errorf("validation failed")
+	}
+	return nil
+}